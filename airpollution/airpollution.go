@@ -3,26 +3,23 @@
 package airpollution
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
-	"os"
 	"strconv"
-)
+	"time"
 
-var baseURL = url.URL{
-	Scheme: "https",
-	Host:   "api.openweathermap.org",
-	Path:   "/data/2.5/air_pollution",
-}
+	"github.com/insomniacslk/openweathermap"
+	"github.com/insomniacslk/openweathermap/internal/apiutil"
+)
 
 // DefaultLimit is the default maximum number of geocoding results to be
 // returned.
 const DefaultLimit = 5
 
 // FailureResponse is the response structure used when an API call has failed.
+//
+// Deprecated: failed requests are reported as *openweathermap.APIError.
 type FailureResponse struct {
 	Cod     int    `json:"cod"`
 	Message string `json:"message"`
@@ -64,27 +61,70 @@ type Response struct {
 		Lat float64 `json:"lat"`
 		Lon float64 `json:"lon"`
 	} `json:"coord"`
-	List []struct {
-		Dt   int64 `json:"dt"`
-		Main struct {
-			AQI int `json:"aqi"`
-		} `json:"main"`
-		Components struct {
-			CO   float64 `json:"co"`
-			NO   float64 `json:"no"`
-			NO2  float64 `json:"no2"`
-			O3   float64 `json:"o3"`
-			SO2  float64 `json:"so2"`
-			PM25 float64 `json:"pm2_5"`
-			PM10 float64 `json:"pm10"`
-			NH3  float64 `json:"nh3"`
-		} `json:"components"`
-	} `json:"list"`
+	List []Item `json:"list"`
+}
+
+// Item is an element of Response.List, with the air quality at a given time.
+type Item struct {
+	Dt   int64 `json:"dt"`
+	Main struct {
+		AQI int `json:"aqi"`
+	} `json:"main"`
+	Components struct {
+		CO   float64 `json:"co"`
+		NO   float64 `json:"no"`
+		NO2  float64 `json:"no2"`
+		O3   float64 `json:"o3"`
+		SO2  float64 `json:"so2"`
+		PM25 float64 `json:"pm2_5"`
+		PM10 float64 `json:"pm10"`
+		NH3  float64 `json:"nh3"`
+	} `json:"components"`
+}
+
+// Time returns the time of the data point in the given location, or the
+// zero time if it is not reported. The air pollution API does not report the
+// time zone of the requested coordinates, so it has to be provided by the
+// caller, e.g. from a One Call response. If loc is nil, UTC is used.
+func (i *Item) Time(loc *time.Location) time.Time {
+	return apiutil.UnixTime(i.Dt, loc)
+}
+
+// Client is an air pollution API client.
+type Client struct {
+	owm *openweathermap.Client
+}
+
+// NewClient returns an air pollution API client that executes its requests
+// with the given OpenWeatherMap client.
+func NewClient(c *openweathermap.Client) *Client {
+	return &Client{owm: c}
 }
 
 // Request executes an air pollution request.
+// If debug is true, requests are logged to stderr.
+//
+// Deprecated: use Client.Request instead.
 func Request(appID string, req *AirPollutionRequest, debug bool) (*Response, error) {
-	u := baseURL // copy
+	owm := openweathermap.NewClient(appID)
+	if debug {
+		owm.Logger = openweathermap.DebugLogger()
+	}
+	return NewClient(owm).Request(req)
+}
+
+// Request executes an air pollution request.
+func (c *Client) Request(req *AirPollutionRequest) (*Response, error) {
+	return c.RequestContext(context.Background(), req)
+}
+
+// RequestContext is like Request, but the request is bound to the given
+// context.
+func (c *Client) RequestContext(ctx context.Context, req *AirPollutionRequest) (*Response, error) {
+	u, err := c.owm.URL(openweathermap.AirPollutionAPI, "")
+	if err != nil {
+		return nil, err
+	}
 	q := u.Query()
 	q.Set("lat", strconv.FormatFloat(req.Lat, 'f', -1, 32))
 	q.Set("lon", strconv.FormatFloat(req.Lon, 'f', -1, 32))
@@ -94,41 +134,15 @@ func Request(appID string, req *AirPollutionRequest, debug bool) (*Response, err
 	if req.End != 0 {
 		q.Set("end", strconv.FormatInt(req.End, 10))
 	}
-	q.Set("appid", appID)
 	u.RawQuery = q.Encode()
 
-	if debug {
-		fmt.Fprintf(os.Stderr, "URL: %s", u.String())
-	}
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return nil, fmt.Errorf("HTTP GET failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := c.owm.GetContext(ctx, u)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read HTTP body: %w", err)
-	}
-	if debug {
-		fmt.Fprintf(os.Stderr, "Response: %s", string(body))
-	}
-	// first check if the call has failed
-	if resp.StatusCode != 200 {
-		var fresp FailureResponse
-		if err := json.Unmarshal(body, &fresp); err != nil {
-			return nil, fmt.Errorf("HTTP GET returned status '%s', and could not unmarshal response message: %w", resp.Status, err)
-		}
-		return nil, fmt.Errorf("Request failed with %d: %s", fresp.Cod, fresp.Message)
+		return nil, err
 	}
-
 	var apiResp Response
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
 	}
-
-	if debug {
-		fmt.Fprintf(os.Stderr, "%s\n", string(body))
-	}
 	return &apiResp, nil
 }
@@ -0,0 +1,277 @@
+package openweathermap
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the raw bodies of successful API responses, keyed on the
+// normalized request URL without the app ID, prefixed by a hash of the app
+// ID: clients with different keys, and so possibly with different
+// subscriptions, do not share entries, and the key is not stored in clear.
+// Implementations must be safe for concurrent use. Caching is best-effort,
+// so implementations should report any failure as a cache miss.
+type Cache interface {
+	// Get returns the value stored for key, and whether it was found and
+	// not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value for key, for the given time to live.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// Endpoint identifies an API endpoint by its family and by its path within
+// the family, as passed to Client.URL, e.g. Endpoint{API: OneCallAPI, Path:
+// "/timemachine"}. The empty path is the family's root.
+type Endpoint struct {
+	API  API
+	Path string
+}
+
+// DefaultCacheTTL is the time to live of cached responses for each endpoint,
+// used when Client.CacheTTL is nil. Endpoints with no entry use the entry of
+// their family's root. Historical data does not change, so timemachine and
+// day summary responses are kept for a day, and geocoding results, that
+// seldom change, for a week.
+var DefaultCacheTTL = map[Endpoint]time.Duration{
+	{OneCallAPI, ""}:              10 * time.Minute,
+	{OneCallAPI, "/timemachine"}:  24 * time.Hour,
+	{OneCallAPI, "/day_summary"}:  24 * time.Hour,
+	{GeocodingAPI, ""}:            7 * 24 * time.Hour,
+	{AirPollutionAPI, ""}:         30 * time.Minute,
+	{DataAPI, ""}:                 10 * time.Minute,
+	{DataAPI, "/forecast"}:        time.Hour,
+	{DataAPI, "/forecast/daily"}:  3 * time.Hour,
+	{ProAPI, ""}:                  10 * time.Minute,
+	{ProAPI, "/forecast/hourly"}:  time.Hour,
+	{ProAPI, "/forecast/climate"}: 24 * time.Hour,
+}
+
+// MemoryCache is an in-memory Cache that holds up to a fixed number of
+// entries, evicting the least recently used one when full.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an in-memory LRU cache that holds up to size
+// entries.
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get implements Cache.Get.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set implements Cache.Set.
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size <= 0 {
+		return
+	}
+	expires := time.Now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value, entry.expires = value, expires
+		c.lru.MoveToFront(elem)
+		return
+	}
+	for c.lru.Len() >= c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+}
+
+// DiskCache is a Cache that stores each entry as a file in a directory, so
+// that it survives restarts. Expired entries are removed when accessed, but
+// entries that are never requested again stay on disk until Prune is called,
+// so long running programs should call it periodically.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a cache that stores its entries in dir, creating it
+// if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the file name for the given key. Keys are hashed so that they
+// are valid file names.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get implements Cache.Get. Each file starts with the expiration time as
+// nanoseconds since the epoch, followed by the value.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	p := c.path(key)
+	data, err := os.ReadFile(p)
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	if time.Now().After(expires) {
+		_ = os.Remove(p)
+		return nil, false
+	}
+	return data[8:], true
+}
+
+// Set implements Cache.Set. The file is written atomically, so concurrent
+// readers never see a partial entry.
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	data := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(data[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(data[8:], value)
+
+	f, err := os.CreateTemp(c.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+}
+
+// Prune removes the expired entries, and the temporary files left over by
+// interrupted writes.
+func (c *DiskCache) Prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		p := filepath.Join(c.dir, entry.Name())
+		if strings.HasPrefix(entry.Name(), "tmp-") {
+			// leave alone the files that may still be being written.
+			if info, err := entry.Info(); err == nil && now.Sub(info.ModTime()) > time.Minute {
+				_ = os.Remove(p)
+			}
+			continue
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			continue
+		}
+		var header [8]byte
+		_, err = io.ReadFull(f, header[:])
+		f.Close()
+		if err != nil || now.After(time.Unix(0, int64(binary.BigEndian.Uint64(header[:])))) {
+			_ = os.Remove(p)
+		}
+	}
+	return nil
+}
+
+// cacheKey returns the cache key for the given normalized URL without the
+// app ID.
+func (c *Client) cacheKey(u string) string {
+	sum := sha256.Sum256([]byte(c.AppID))
+	return hex.EncodeToString(sum[:8]) + " " + u
+}
+
+// cacheTTL returns the time to live for responses from the given URL,
+// according to its endpoint, or to its family's root if the endpoint has no
+// entry. It returns zero if the responses should not be cached.
+func (c *Client) cacheTTL(u *url.URL) time.Duration {
+	endpoint, ok := c.endpointFor(u)
+	if !ok {
+		return 0
+	}
+	ttls := c.CacheTTL
+	if ttls == nil {
+		ttls = DefaultCacheTTL
+	}
+	if ttl, ok := ttls[endpoint]; ok {
+		return ttl
+	}
+	return ttls[Endpoint{API: endpoint.API}]
+}
+
+// endpointFor returns the endpoint that the given URL belongs to. Its family
+// is the one whose URL is the longest prefix of the given one, and its path
+// is the rest of the URL.
+func (c *Client) endpointFor(u *url.URL) (Endpoint, bool) {
+	target := *u // copy
+	target.RawQuery = ""
+	apis := make([]API, 0, len(apiPaths)+len(c.Endpoints))
+	for api := range apiPaths {
+		apis = append(apis, api)
+	}
+	for api := range c.Endpoints {
+		apis = append(apis, api)
+	}
+	var (
+		found   Endpoint
+		longest int
+	)
+	for _, api := range apis {
+		base, err := c.URL(api, "")
+		if err != nil {
+			continue
+		}
+		prefix := strings.TrimSuffix(base.String(), "/")
+		path, ok := strings.CutPrefix(target.String(), prefix)
+		if !ok || (path != "" && path[0] != '/') {
+			continue
+		}
+		if len(prefix) > longest {
+			found, longest = Endpoint{API: api, Path: strings.TrimSuffix(path, "/")}, len(prefix)
+		}
+	}
+	return found, longest > 0
+}
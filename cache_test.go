@@ -0,0 +1,108 @@
+package openweathermap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiskCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("expired", []byte("old"), time.Nanosecond)
+	c.Set("fresh", []byte("new"), time.Hour)
+	time.Sleep(time.Millisecond)
+	if err := c.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries after pruning, want 1", len(entries))
+	}
+	if body, ok := c.Get("fresh"); !ok || string(body) != "new" {
+		t.Errorf("the fresh entry was not kept: %q, %v", body, ok)
+	}
+}
+
+func TestCacheKeyAppID(t *testing.T) {
+	a, b := NewClient("key1"), NewClient("key2")
+	u := "https://api.openweathermap.org/data/3.0/onecall?lat=1&lon=2"
+	if a.cacheKey(u) == b.cacheKey(u) {
+		t.Error("clients with different app IDs share the cache key")
+	}
+	if a.cacheKey(u) != NewClient("key1").cacheKey(u) {
+		t.Error("clients with the same app ID have different cache keys")
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	c := NewClient("key")
+	c.Endpoints = map[API]string{DataAPI: "http://127.0.0.1:8080/data"}
+	for _, tc := range []struct {
+		api  API
+		path string
+		want time.Duration
+	}{
+		{OneCallAPI, "", 10 * time.Minute},
+		{OneCallAPI, "/timemachine", 24 * time.Hour},
+		{OneCallAPI, "/day_summary", 24 * time.Hour},
+		{OneCallAPI, "/overview", 10 * time.Minute},
+		{GeocodingAPI, "/direct", 7 * 24 * time.Hour},
+		{AirPollutionAPI, "", 30 * time.Minute},
+		{DataAPI, "/weather", 10 * time.Minute},
+		{DataAPI, "/forecast", time.Hour},
+		{DataAPI, "/forecast/daily", 3 * time.Hour},
+		{ProAPI, "/forecast/hourly", time.Hour},
+		{ProAPI, "/forecast/climate", 24 * time.Hour},
+	} {
+		u, err := c.URL(tc.api, tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u.RawQuery = "lat=1&lon=2"
+		if got := c.cacheTTL(u); got != tc.want {
+			t.Errorf("%s %q: got TTL %s, want %s", tc.api, tc.path, got, tc.want)
+		}
+	}
+
+	c.CacheTTL = map[Endpoint]time.Duration{{API: OneCallAPI}: time.Minute}
+	for path, want := range map[string]time.Duration{"": time.Minute, "/timemachine": time.Minute} {
+		u, _ := c.URL(OneCallAPI, path)
+		if got := c.cacheTTL(u); got != want {
+			t.Errorf("custom %q: got TTL %s, want %s", path, got, want)
+		}
+	}
+	u, _ := c.URL(DataAPI, "/weather")
+	if got := c.cacheTTL(u); got != 0 {
+		t.Errorf("got TTL %s for an endpoint with no entry, want 0", got)
+	}
+}
+
+func TestCacheExcludeOrder(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"lat":1,"lon":2}`))
+	}))
+	defer srv.Close()
+	c := NewClient("key")
+	c.Endpoints = map[API]string{OneCallAPI: srv.URL + "/onecall"}
+	c.Cache = NewMemoryCache(10)
+	if _, err := c.Request(1, 2, []Exclude{Daily, Hourly}, Metric, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Request(1, 2, []Exclude{Hourly, Daily}, Metric, ""); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls to the server, want 1", calls)
+	}
+}
@@ -0,0 +1,342 @@
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the scheme and host of OpenWeatherMap's API server.
+const DefaultBaseURL = "https://api.openweathermap.org"
+
+// DefaultProBaseURL is the scheme and host of OpenWeatherMap's server for the
+// APIs that require a paid subscription, e.g. the hourly forecast.
+const DefaultProBaseURL = "https://pro.openweathermap.org"
+
+// API identifies a family of OpenWeatherMap APIs that share the same path
+// prefix, and that can be redirected to a different server with
+// Client.Endpoints.
+type API string
+
+// supported API families.
+const (
+	OneCallAPI      API = "onecall"
+	GeocodingAPI    API = "geocoding"
+	AirPollutionAPI API = "airpollution"
+	DataAPI         API = "data"
+	ProAPI          API = "pro"
+)
+
+// apiPaths maps each API family to its path prefix on DefaultBaseURL, or on
+// DefaultProBaseURL for ProAPI.
+var apiPaths = map[API]string{
+	OneCallAPI:      "/data/3.0/onecall",
+	GeocodingAPI:    "/geo/1.0",
+	AirPollutionAPI: "/data/2.5/air_pollution",
+	DataAPI:         "/data/2.5",
+	ProAPI:          "/data/2.5",
+}
+
+// Client is an OpenWeatherMap API client. The same client is shared by the
+// One Call API in this package and by the other API packages, e.g. geocoding
+// and current, so that the HTTP transport, proxies and timeouts can be
+// configured once.
+type Client struct {
+	// AppID is the OpenWeatherMap app ID, a.k.a. API key.
+	AppID string
+	// HTTPClient is used to execute the HTTP requests. If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+	// BaseURL is the URL that the API paths are resolved against. If empty,
+	// DefaultBaseURL is used.
+	BaseURL string
+	// ProBaseURL is the URL that the paths of ProAPI are resolved against.
+	// If empty, DefaultProBaseURL is used.
+	ProBaseURL string
+	// Endpoints overrides the URL of single API families, e.g. to point them
+	// to a local test server or to a proxy. Each value replaces both BaseURL
+	// and the family's path prefix, so for example setting OneCallAPI to
+	// "http://127.0.0.1:8080/onecall" sends timemachine requests to
+	// "http://127.0.0.1:8080/onecall/timemachine".
+	Endpoints map[API]string
+	// UserAgent, if not empty, is sent as the User-Agent header.
+	UserAgent string
+	// Units are the default units, used when a request does not specify any.
+	Units Units
+	// Lang is the default language, used when a request does not specify
+	// any.
+	Lang Lang
+	// Retry, if not nil, enables retrying the requests that fail with a
+	// transient error.
+	Retry *RetryPolicy
+	// Cache, if not nil, stores successful responses and serves repeated
+	// requests without calling the API. Cache hits do not count against
+	// Limiter.
+	Cache Cache
+	// CacheTTL maps each endpoint to the time to live of its cached
+	// responses. Endpoints with no entry use the entry of their family's
+	// root, e.g. Endpoint{API: DataAPI}. Endpoints with neither, or with a
+	// non-positive TTL, are not cached. If nil, DefaultCacheTTL is used.
+	CacheTTL map[Endpoint]time.Duration
+	// Limiter, if not nil, keeps the calls within a per-minute and per-day
+	// budget. Every attempt counts as a call, including retries.
+	Limiter *RateLimiter
+	// Logger, if not nil, receives a debug event for every request and
+	// response, with timing and status. The app ID is redacted from the
+	// logged URLs.
+	Logger *slog.Logger
+}
+
+// NewClient returns a new client for the given app ID, using the default
+// HTTP client and base URLs.
+func NewClient(appID string) *Client {
+	return &Client{
+		AppID:      appID,
+		HTTPClient: http.DefaultClient,
+		BaseURL:    DefaultBaseURL,
+		ProBaseURL: DefaultProBaseURL,
+	}
+}
+
+// DebugLogger returns a logger that writes all the events, including the
+// debug ones, as text to stderr. It is what the deprecated functions with a
+// debug flag use.
+func DebugLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// failureResponse is the response structure used by all the APIs when a call
+// has failed. Some APIs return the code as a string, others as a number.
+type failureResponse struct {
+	Cod     json.Number `json:"cod"`
+	Message string      `json:"message"`
+}
+
+// URL returns the URL for the given path within an API family. The path is
+// appended to the family's endpoint if overridden in Endpoints, or otherwise
+// to BaseURL, or ProBaseURL for ProAPI, and the family's default path prefix.
+func (c *Client) URL(api API, path string) (*url.URL, error) {
+	endpoint, ok := c.Endpoints[api]
+	if !ok {
+		prefix, ok := apiPaths[api]
+		if !ok {
+			return nil, fmt.Errorf("unknown API '%s'", api)
+		}
+		baseURL := c.BaseURL
+		if baseURL == "" {
+			baseURL = DefaultBaseURL
+		}
+		if api == ProAPI {
+			baseURL = c.ProBaseURL
+			if baseURL == "" {
+				baseURL = DefaultProBaseURL
+			}
+		}
+		endpoint = strings.TrimSuffix(baseURL, "/") + prefix
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL for API '%s': %w", api, err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	return u, nil
+}
+
+// Get executes an HTTP GET request to the given URL after adding the app ID
+// to its query, and returns the response body. An error is returned if the
+// API call has failed.
+func (c *Client) Get(u *url.URL) ([]byte, error) {
+	return c.GetContext(context.Background(), u)
+}
+
+// GetContext is like Get, but the request is bound to the given context. If
+// the context is canceled or its deadline expires while the request is in
+// flight, while the body is being read or while waiting to retry, the
+// context's error is returned.
+func (c *Client) GetContext(ctx context.Context, u *url.URL) ([]byte, error) {
+	reqURL := *u // copy
+	q := reqURL.Query()
+	q.Del("appid")
+	// Encode sorts the parameters, so equivalent requests share the key.
+	reqURL.RawQuery = q.Encode()
+	logURL := reqURL.String()
+	cacheKey := c.cacheKey(logURL)
+	var ttl time.Duration
+	if c.Cache != nil {
+		ttl = c.cacheTTL(&reqURL)
+		if ttl > 0 {
+			if body, ok := c.Cache.Get(cacheKey); ok {
+				c.log(ctx, slog.LevelDebug, "cache hit", slog.String("url", logURL))
+				return body, nil
+			}
+		}
+	}
+	q.Set("appid", c.AppID)
+	reqURL.RawQuery = q.Encode()
+
+	for attempt := 1; ; attempt++ {
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		body, retryable, err := c.do(ctx, &reqURL)
+		if err == nil {
+			if ttl > 0 {
+				c.Cache.Set(cacheKey, body, ttl)
+			}
+			return body, nil
+		}
+		if !retryable || c.Retry == nil || attempt >= c.Retry.MaxAttempts {
+			return nil, err
+		}
+		delay := c.Retry.backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+			if apiErr.RetryAfter > c.Retry.maxRetryAfter() {
+				return nil, err
+			}
+			delay = apiErr.RetryAfter
+		}
+		c.log(ctx, slog.LevelWarn, "retrying request",
+			slog.String("url", logURL),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			slog.Any("error", err),
+		)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do executes a single HTTP GET request. On failure, it also returns whether
+// the request can be retried.
+func (c *Client) do(ctx context.Context, reqURL *url.URL) ([]byte, bool, error) {
+	logURL := redactURL(reqURL)
+	c.log(ctx, slog.LevelDebug, "request", slog.String("url", logURL))
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		redactError(err, logURL)
+		return nil, false, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		redactError(err, logURL)
+		c.log(ctx, slog.LevelDebug, "request failed",
+			slog.String("url", logURL),
+			slog.Duration("duration", time.Since(start)),
+			slog.Any("error", err),
+		)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, false, ctxErr
+		}
+		return nil, true, fmt.Errorf("HTTP GET failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, false, ctxErr
+		}
+		return nil, true, fmt.Errorf("failed to read HTTP body: %w", err)
+	}
+	// do not hand the body over for decoding if the caller has gone away in
+	// the meantime.
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	c.log(ctx, slog.LevelDebug, "response",
+		slog.String("url", logURL),
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("size", len(body)),
+		slog.String("body", string(body)),
+	)
+	// first check if the call has failed
+	if resp.StatusCode != 200 {
+		return nil, isRetryableStatus(resp.StatusCode), newAPIError(resp, reqURL, body)
+	}
+	return body, false, nil
+}
+
+// log emits a log record if the client has a logger.
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.Logger == nil {
+		return
+	}
+	c.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// redactURL returns the given URL as a string, with the app ID redacted.
+func redactURL(u *url.URL) string {
+	redacted := *u // copy
+	q := redacted.Query()
+	if q.Has("appid") {
+		q.Set("appid", "REDACTED")
+	}
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+// redactError replaces the URL in a *url.Error, as returned by the HTTP
+// client, with the given redacted URL, so that the app ID does not end up in
+// logs and error messages.
+func redactError(err error, redactedURL string) {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactedURL
+	}
+}
+
+// newAPIError builds an APIError out of a failed HTTP response.
+func newAPIError(resp *http.Response, u *url.URL, body []byte) *APIError {
+	endpoint := *u // copy
+	endpoint.RawQuery = ""
+	apiErr := APIError{
+		StatusCode: resp.StatusCode,
+		Cod:        resp.StatusCode,
+		Message:    resp.Status,
+		Endpoint:   endpoint.String(),
+		Body:       body,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+	var fresp failureResponse
+	if err := json.Unmarshal(body, &fresp); err == nil {
+		if cod, err := fresp.Cod.Int64(); err == nil {
+			apiErr.Cod = int(cod)
+		}
+		if fresp.Message != "" {
+			apiErr.Message = fresp.Message
+		}
+	}
+	return &apiErr
+}
+
+func (c *Client) units(units Units) Units {
+	if units == "" {
+		return c.Units
+	}
+	return units
+}
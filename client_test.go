@@ -0,0 +1,150 @@
+package openweathermap
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestClientURL(t *testing.T) {
+	c := &Client{
+		BaseURL:    "http://base.example/",
+		ProBaseURL: "http://pro.example",
+		Endpoints: map[API]string{
+			GeocodingAPI: "http://127.0.0.1:8080/geo/",
+		},
+	}
+	for _, tc := range []struct {
+		api  API
+		path string
+		want string
+	}{
+		{OneCallAPI, "", "http://base.example/data/3.0/onecall"},
+		{OneCallAPI, "/timemachine", "http://base.example/data/3.0/onecall/timemachine"},
+		{AirPollutionAPI, "", "http://base.example/data/2.5/air_pollution"},
+		{DataAPI, "/weather", "http://base.example/data/2.5/weather"},
+		{ProAPI, "/forecast/hourly", "http://pro.example/data/2.5/forecast/hourly"},
+		{GeocodingAPI, "/direct", "http://127.0.0.1:8080/geo/direct"},
+	} {
+		u, err := c.URL(tc.api, tc.path)
+		if err != nil {
+			t.Errorf("%s %q: %v", tc.api, tc.path, err)
+			continue
+		}
+		if got := u.String(); got != tc.want {
+			t.Errorf("%s %q: got %s, want %s", tc.api, tc.path, got, tc.want)
+		}
+	}
+	if _, err := c.URL("nope", ""); err == nil {
+		t.Error("no error for an unknown API")
+	}
+
+	// the zero client uses the default servers.
+	var zero Client
+	u, _ := zero.URL(OneCallAPI, "")
+	if got, want := u.String(), DefaultBaseURL+"/data/3.0/onecall"; got != want {
+		t.Errorf("zero client: got %s, want %s", got, want)
+	}
+	u, _ = zero.URL(ProAPI, "/forecast/hourly")
+	if got, want := u.String(), DefaultProBaseURL+"/data/2.5/forecast/hourly"; got != want {
+		t.Errorf("zero client: got %s, want %s", got, want)
+	}
+}
+
+func TestClientEndpoints(t *testing.T) {
+	var requests []*url.URL
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL)
+		if strings.HasSuffix(r.URL.Path, "/denied") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"cod":401,"message":"Invalid API key"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("SECRETKEY")
+	c.BaseURL = srv.URL
+	c.ProBaseURL = srv.URL + "/pro"
+	c.Endpoints = map[API]string{GeocodingAPI: srv.URL + "/geo-proxy"}
+	for _, tc := range []struct {
+		api  API
+		path string
+		want string
+	}{
+		{OneCallAPI, "", "/data/3.0/onecall"},
+		{OneCallAPI, "/timemachine", "/data/3.0/onecall/timemachine"},
+		{DataAPI, "/weather", "/data/2.5/weather"},
+		{ProAPI, "/forecast/hourly", "/pro/data/2.5/forecast/hourly"},
+		{GeocodingAPI, "/direct", "/geo-proxy/direct"},
+	} {
+		requests = nil
+		u, err := c.URL(tc.api, tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u.RawQuery = "lat=1&lon=2"
+		if _, err := c.Get(u); err != nil {
+			t.Errorf("%s %q: %v", tc.api, tc.path, err)
+			continue
+		}
+		if len(requests) != 1 {
+			t.Fatalf("%s %q: got %d requests, want 1", tc.api, tc.path, len(requests))
+		}
+		r := requests[0]
+		if r.Path != tc.want {
+			t.Errorf("%s %q: got path %s, want %s", tc.api, tc.path, r.Path, tc.want)
+		}
+		if got := r.Query().Get("appid"); got != "SECRETKEY" {
+			t.Errorf("%s %q: got appid %q, want %q", tc.api, tc.path, got, "SECRETKEY")
+		}
+		if got := r.Query().Get("lat"); got != "1" {
+			t.Errorf("%s %q: got lat %q, want %q", tc.api, tc.path, got, "1")
+		}
+	}
+
+	// the app ID is not leaked by the API errors.
+	u, _ := c.URL(OneCallAPI, "/denied")
+	_, err := c.Get(u)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got error %v, want an *APIError", err)
+	}
+	if want := srv.URL + "/data/3.0/onecall/denied"; apiErr.Endpoint != want {
+		t.Errorf("got endpoint %s, want %s", apiErr.Endpoint, want)
+	}
+	if strings.Contains(err.Error(), "SECRETKEY") {
+		t.Errorf("the app ID is leaked by the API error: %v", err)
+	}
+}
+
+func TestClientRedactsNetworkErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // refuse the connections.
+
+	var logs bytes.Buffer
+	c := NewClient("SECRETKEY")
+	c.BaseURL = srv.URL
+	c.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c.Retry = &RetryPolicy{MaxAttempts: 2, InitialBackoff: 1}
+	_, err := c.Request(1, 2, nil, Metric, "")
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("got error %v, want a *url.Error", err)
+	}
+	if strings.Contains(err.Error(), "SECRETKEY") {
+		t.Errorf("the app ID is leaked by the error: %v", err)
+	}
+	if !strings.Contains(err.Error(), "appid=REDACTED") {
+		t.Errorf("the error does not contain the redacted URL: %v", err)
+	}
+	if strings.Contains(logs.String(), "SECRETKEY") {
+		t.Errorf("the app ID is leaked by the logs:\n%s", logs.String())
+	}
+}
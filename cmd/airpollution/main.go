@@ -6,6 +6,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/insomniacslk/openweathermap"
 	"github.com/insomniacslk/openweathermap/airpollution"
 	"github.com/spf13/pflag"
 )
@@ -25,15 +26,17 @@ func main() {
 		err  error
 		resp *airpollution.Response
 	)
-	resp, err = airpollution.Request(
-		*flagAppID,
+	c := openweathermap.NewClient(*flagAppID)
+	if *flagDebug {
+		c.Logger = openweathermap.DebugLogger()
+	}
+	resp, err = airpollution.NewClient(c).Request(
 		&airpollution.AirPollutionRequest{
 			Lat:   *flagLat,
 			Lon:   *flagLon,
 			Start: *flagStart,
 			End:   *flagEnd,
 		},
-		*flagDebug,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -42,7 +45,7 @@ func main() {
 	fmt.Fprintf(w, "Coord             : %f,%f\n", resp.Coord.Lat, resp.Coord.Lon)
 	for idx, item := range resp.List {
 		fmt.Fprintf(w, "Item #%d\n", idx+1)
-		fmt.Fprintf(w, "Datetime          : %s\n", time.Unix(item.Dt, 0))
+		fmt.Fprintf(w, "Datetime          : %s\n", item.Time(time.Local))
 		fmt.Fprintf(w, "Air Quality Index : %d\n", item.Main.AQI)
 		fmt.Fprintf(w, "CO                : %.03f\n", item.Components.CO)
 		fmt.Fprintf(w, "NO                : %.03f\n", item.Components.NO)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/insomniacslk/openweathermap"
 	"github.com/insomniacslk/openweathermap/find"
@@ -19,22 +20,80 @@ var (
 
 func main() {
 	pflag.Parse()
-	resp, err := find.Request(
-		*flagAppID,
+	c := openweathermap.NewClient(*flagAppID)
+	if *flagDebug {
+		c.Logger = openweathermap.DebugLogger()
+	}
+	resp, err := find.NewClient(c).Request(
 		*flagQuery,
 		openweathermap.Units(*flagUnits),
-		*flagDebug,
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
+	units := openweathermap.Units(*flagUnits)
+	tempUnit := openweathermap.TempUnits[units]
+	speedUnit := openweathermap.SpeedUnits[units]
 	w := os.Stdout
-	fmt.Fprintf(w, "Count:         %d\n", len((*resp).List))
-	for _, item := range (*resp).List {
-		fmt.Fprintf(w, "ID:        %d\n", item.ID)
-		fmt.Fprintf(w, "Name:      %s\n", item.Name)
-		fmt.Fprintf(w, "Latitude:  %f\n", item.Coord.Lat)
-		fmt.Fprintf(w, "Longitude: %f\n", item.Coord.Lon)
-		// TODO print the remaining fields
+	fmt.Fprintf(w, "Cod:            %s\n", resp.Cod)
+	fmt.Fprintf(w, "Message:        %s\n", resp.Message)
+	fmt.Fprintf(w, "Count:          %d\n", resp.Count)
+	for _, item := range resp.List {
+		// use the city's time zone when the response reports it.
+		loc := item.Location()
+		if loc == nil {
+			loc = time.Local
+		}
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "ID:             %d\n", item.ID)
+		fmt.Fprintf(w, "Name:           %s\n", item.Name)
+		fmt.Fprintf(w, "Country:        %s\n", item.Sys.Country)
+		fmt.Fprintf(w, "Latitude:       %f\n", item.Coord.Lat)
+		fmt.Fprintf(w, "Longitude:      %f\n", item.Coord.Lon)
+		if item.Sys.Timezone != nil {
+			fmt.Fprintf(w, "Timezone:       %s\n", time.Duration(*item.Sys.Timezone)*time.Second)
+		}
+		fmt.Fprintf(w, "Timestamp:      %s\n", item.Time(loc))
+		if sunrise := item.SunriseTime(loc); !sunrise.IsZero() {
+			fmt.Fprintf(w, "Sunrise:        %s\n", sunrise.Format("15:04:05"))
+		}
+		if sunset := item.SunsetTime(loc); !sunset.IsZero() {
+			fmt.Fprintf(w, "Sunset:         %s\n", sunset.Format("15:04:05"))
+		}
+		fmt.Fprintf(w, "Temperature:    %.02f%s\n", item.Main.Temp, tempUnit)
+		fmt.Fprintf(w, "Feels like:     %.02f%s\n", item.Main.FeelsLike, tempUnit)
+		fmt.Fprintf(w, "Min temp:       %.02f%s\n", item.Main.TempMin, tempUnit)
+		fmt.Fprintf(w, "Max temp:       %.02f%s\n", item.Main.TempMax, tempUnit)
+		fmt.Fprintf(w, "Pressure:       %.0f hPa\n", item.Main.Pressure)
+		if item.Main.SeaLevel != 0 {
+			fmt.Fprintf(w, "Sea level:      %.0f hPa\n", item.Main.SeaLevel)
+		}
+		if item.Main.GrndLevel != 0 {
+			fmt.Fprintf(w, "Ground level:   %.0f hPa\n", item.Main.GrndLevel)
+		}
+		fmt.Fprintf(w, "Humidity:       %d%%\n", item.Main.Humidity)
+		fmt.Fprintf(w, "Clouds:         %d%%\n", item.Clouds.All)
+		fmt.Fprintf(w, "Visibility:     %dm\n", item.Visibility)
+		fmt.Fprintf(w, "Wind speed:     %.02f %s\n", item.Wind.Speed, speedUnit)
+		dir := item.Wind.Direction()
+		fmt.Fprintf(w, "Wind direction: %s %s (%.0f degrees)\n", dir.Arrow(), dir.Compass16(), dir.Degrees())
+		if item.Wind.Gust != nil {
+			fmt.Fprintf(w, "Wind gust:      %.02f %s\n", *item.Wind.Gust, speedUnit)
+		}
+		if item.Rain.OneHour != nil {
+			fmt.Fprintf(w, "Rain (1h):      %.02f mm\n", *item.Rain.OneHour)
+		}
+		if item.Rain.ThreeHours != nil {
+			fmt.Fprintf(w, "Rain (3h):      %.02f mm\n", *item.Rain.ThreeHours)
+		}
+		if item.Snow.OneHour != nil {
+			fmt.Fprintf(w, "Snow (1h):      %.02f mm\n", *item.Snow.OneHour)
+		}
+		if item.Snow.ThreeHours != nil {
+			fmt.Fprintf(w, "Snow (3h):      %.02f mm\n", *item.Snow.ThreeHours)
+		}
+		for _, wea := range item.Weather {
+			fmt.Fprintf(w, "Weather:        %d %s: %s (icon %s)\n", wea.ID, wea.Main, wea.Description, wea.Icon)
+		}
 	}
 }
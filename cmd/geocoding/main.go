@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/insomniacslk/openweathermap"
 	"github.com/insomniacslk/openweathermap/geocoding"
 	"github.com/spf13/pflag"
 )
@@ -27,26 +28,27 @@ func main() {
 		err  error
 		resp *geocoding.Response
 	)
+	c := openweathermap.NewClient(*flagAppID)
+	if *flagDebug {
+		c.Logger = openweathermap.DebugLogger()
+	}
+	gc := geocoding.NewClient(c)
 	if *flagReverse {
-		resp, err = geocoding.ReverseGeocoding(
-			*flagAppID,
+		resp, err = gc.ReverseGeocoding(
 			&geocoding.ReverseGeocodingRequest{
 				Lat:   *flagLatitude,
 				Lon:   *flagLongitude,
 				Limit: *flagLimit,
 			},
-			*flagDebug,
 		)
 	} else {
-		resp, err = geocoding.DirectGeocoding(
-			*flagAppID,
+		resp, err = gc.DirectGeocoding(
 			&geocoding.DirectGeocodingRequest{
 				City:        *flagCity,
 				State:       *flagState,
 				CountryCode: *flagCountry,
 				Limit:       *flagLimit,
 			},
-			*flagDebug,
 		)
 	}
 	if err != nil {
@@ -9,17 +9,21 @@ import (
 	"time"
 
 	"github.com/insomniacslk/openweathermap"
+	"github.com/insomniacslk/openweathermap/forecast"
 	"github.com/spf13/pflag"
 )
 
 var (
-	flagAppID   = pflag.StringP("app-id", "a", "", "App ID (a.k.a API key)")
-	flagLat     = pflag.Float64P("latitude", "l", 0.0, "Latitude")
-	flagLon     = pflag.Float64P("longitude", "L", 0.0, "Longitude")
-	flagExclude = pflag.StringP("exclude", "e", "", "Comma-separated list of fields to exclude from the response")
-	flagUnits   = pflag.StringP("units", "u", "standard", "Units to request for response")
-	flagLang    = pflag.StringP("language", "g", string(openweathermap.EN), "Language to request for response")
-	flagDebug   = pflag.BoolP("debug", "d", false, "Enable debug output")
+	flagAppID    = pflag.StringP("app-id", "a", "", "App ID (a.k.a API key)")
+	flagLat      = pflag.Float64P("latitude", "l", 0.0, "Latitude")
+	flagLon      = pflag.Float64P("longitude", "L", 0.0, "Longitude")
+	flagExclude  = pflag.StringP("exclude", "e", "", "Comma-separated list of fields to exclude from the response")
+	flagUnits    = pflag.StringP("units", "u", "standard", "Units to request for response")
+	flagLang     = pflag.StringP("language", "g", string(openweathermap.EN), "Language to request for response")
+	flagDebug    = pflag.BoolP("debug", "d", false, "Enable debug output")
+	flagOverview = pflag.BoolP("overview", "o", false, "Only print a human-readable overview of today's weather")
+	flagForecast = pflag.BoolP("forecast", "f", false, "Use the 5 day / 3 hour forecast API, available with the free subscription")
+	flagClimate  = pflag.BoolP("climate", "c", false, "Use the 30 day climatic forecast API")
 )
 
 func main() {
@@ -28,23 +32,61 @@ func main() {
 	for _, e := range strings.Split(*flagExclude, ",") {
 		excludes = append(excludes, openweathermap.Exclude(e))
 	}
-	resp, err := openweathermap.Request(
-		*flagAppID,
-		*flagLat,
-		*flagLon,
-		excludes,
-		openweathermap.Units(*flagUnits),
-		openweathermap.Lang(*flagLang),
-		*flagDebug,
-	)
-	if err != nil {
-		log.Fatal(err)
+	c := openweathermap.NewClient(*flagAppID)
+	if *flagDebug {
+		c.Logger = openweathermap.DebugLogger()
+	}
+	if *flagOverview {
+		overview, err := c.Overview(*flagLat, *flagLon, time.Time{}, openweathermap.Units(*flagUnits))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(overview.WeatherOverview)
+		return
+	}
+	var resp *openweathermap.Weather
+	switch {
+	case *flagForecast:
+		f, err := forecast.NewClient(c).Request(
+			openweathermap.ByCoordinates(*flagLat, *flagLon),
+			0,
+			openweathermap.Units(*flagUnits),
+			openweathermap.Lang(*flagLang),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resp = f.Weather()
+	case *flagClimate:
+		f, err := forecast.NewClient(c).Climate(
+			openweathermap.ByCoordinates(*flagLat, *flagLon),
+			0,
+			openweathermap.Units(*flagUnits),
+			openweathermap.Lang(*flagLang),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resp = f.Weather()
+	default:
+		var err error
+		resp, err = c.Request(
+			*flagLat,
+			*flagLon,
+			excludes,
+			openweathermap.Units(*flagUnits),
+			openweathermap.Lang(*flagLang),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	// prepare units
 	tempUnit := openweathermap.TempUnits[openweathermap.Units(*flagUnits)]
 	speedUnit := openweathermap.SpeedUnits[openweathermap.Units(*flagUnits)]
-	tz := time.FixedZone(resp.Timezone, resp.TimezoneOffset)
+	tz := resp.Location()
+	lang := openweathermap.Lang(*flagLang)
 	w := os.Stdout
 
 	// print location information
@@ -58,7 +100,7 @@ func main() {
 		fmt.Fprintf(w, "Current\n")
 		fmt.Fprintf(w, "  Temperature               : %.02f%s\n", resp.Current.Temp, tempUnit)
 		fmt.Fprintf(w, "  Feels like                : %.02f%s\n", resp.Current.FeelsLike, tempUnit)
-		printCommonWeatherSummary(w, &resp.Current.CommonWeatherSummary, tempUnit, speedUnit, tz)
+		printCommonWeatherSummary(w, &resp.Current.CommonWeatherSummary, tempUnit, speedUnit, tz, lang)
 		if resp.Current.Rain.OneHour != nil {
 			fmt.Fprintf(w, "  Rain (last hour)          : %.02f mm\n", *resp.Current.Rain.OneHour)
 		}
@@ -70,16 +112,20 @@ func main() {
 	// print minutely weather
 	fmt.Fprintf(w, "Minutely\n")
 	for _, minutely := range resp.Minutely {
-		fmt.Fprintf(w, "  Timestamp                 : %s\n", time.Unix(minutely.Dt, 0))
-		fmt.Fprintf(w, "  Precipitation             : %d mm\n", minutely.Precipitation)
+		fmt.Fprintf(w, "  Timestamp                 : %s\n", minutely.Time(tz))
+		fmt.Fprintf(w, "  Precipitation             : %.02f mm/h\n", minutely.Precipitation)
 		fmt.Fprintf(w, "\n")
 	}
 	// print hourly weather
 	fmt.Fprintf(w, "Hourly\n")
+	if dir, ok := openweathermap.MeanHourlyWindDirection(resp.Hourly); ok {
+		fmt.Fprintf(w, "  Mean wind direction       : %s %s (%.0f degrees)\n", dir.Arrow(), dir.Abbrev(lang), dir.Degrees())
+		fmt.Fprintf(w, "\n")
+	}
 	for _, hourly := range resp.Hourly {
 		fmt.Fprintf(w, "  Temperature               : %.02f%s\n", hourly.Temp, tempUnit)
 		fmt.Fprintf(w, "  Feels like                : %.02f%s\n", hourly.FeelsLike, tempUnit)
-		printCommonWeatherSummary(w, &hourly.CommonWeatherSummary, tempUnit, speedUnit, tz)
+		printCommonWeatherSummary(w, &hourly.CommonWeatherSummary, tempUnit, speedUnit, tz, lang)
 		if hourly.Rain.OneHour != nil {
 			fmt.Fprintf(w, "  Rain (last hour)          : %.02f mm\n", *hourly.Rain.OneHour)
 		}
@@ -97,14 +143,27 @@ func main() {
 		fmt.Fprintf(w, "  Temperature (morning)     : %.02f%s\n", daily.Temp.Morn, tempUnit)
 		fmt.Fprintf(w, "  Temperature (evening)     : %.02f%s\n", daily.Temp.Eve, tempUnit)
 		fmt.Fprintf(w, "  Temperature (night)       : %.02f%s\n", daily.Temp.Night, tempUnit)
-		fmt.Fprintf(w, "  Feels like                : %.02f%s\n", daily.FeelsLike, tempUnit)
-		printCommonWeatherSummary(w, &daily.CommonWeatherSummary, tempUnit, speedUnit, tz)
+		fmt.Fprintf(w, "  Feels like (day)          : %.02f%s\n", daily.FeelsLike.Day, tempUnit)
+		fmt.Fprintf(w, "  Feels like (morning)      : %.02f%s\n", daily.FeelsLike.Morn, tempUnit)
+		fmt.Fprintf(w, "  Feels like (evening)      : %.02f%s\n", daily.FeelsLike.Eve, tempUnit)
+		fmt.Fprintf(w, "  Feels like (night)        : %.02f%s\n", daily.FeelsLike.Night, tempUnit)
+		if daily.Summary != "" {
+			fmt.Fprintf(w, "  Summary                   : %s\n", daily.Summary)
+		}
+		printCommonWeatherSummary(w, &daily.CommonWeatherSummary, tempUnit, speedUnit, tz, lang)
 		if daily.Rain != nil {
-			fmt.Fprintf(w, "  Rain (last hour)          : %.02f mm\n", *daily.Rain)
+			fmt.Fprintf(w, "  Rain                      : %.02f mm\n", *daily.Rain)
 		}
 		if daily.Snow != nil {
-			fmt.Fprintf(w, "  Snow (last hour)          : %.02f mm\n", *daily.Snow)
+			fmt.Fprintf(w, "  Snow                      : %.02f mm\n", *daily.Snow)
+		}
+		if moonrise := daily.MoonriseTime(tz); !moonrise.IsZero() {
+			fmt.Fprintf(w, "  Moonrise                  : %s\n", moonrise.Format("15:04:05"))
+		}
+		if moonset := daily.MoonsetTime(tz); !moonset.IsZero() {
+			fmt.Fprintf(w, "  Moonset                   : %s\n", moonset.Format("15:04:05"))
 		}
+		fmt.Fprintf(w, "  Moon phase                : %.02f\n", daily.MoonPhase)
 		fmt.Fprintf(w, "\n")
 	}
 	// print alerts
@@ -113,17 +172,24 @@ func main() {
 		fmt.Fprintf(w, "  Sender name               : %s\n", alert.SenderName)
 		fmt.Fprintf(w, "  Event                     : %s\n", alert.Event)
 		fmt.Fprintf(w, "  Description               : %s\n", alert.Description)
-		fmt.Fprintf(w, "  Start                     : %s\n", time.Unix(alert.Start, 0))
-		fmt.Fprintf(w, "  End                       : %s\n", time.Unix(alert.End, 0))
+		fmt.Fprintf(w, "  Start                     : %s\n", alert.StartTime(tz))
+		fmt.Fprintf(w, "  End                       : %s\n", alert.EndTime(tz))
+		if len(alert.Tags) != 0 {
+			fmt.Fprintf(w, "  Tags                      : %s\n", strings.Join(alert.Tags, ", "))
+		}
 		fmt.Fprintf(w, "\n")
 	}
 }
 
 // function to print the common part of weather summaries.
-func printCommonWeatherSummary(w io.Writer, s *openweathermap.CommonWeatherSummary, tempUnit, speedUnit string, tz *time.Location) {
-	fmt.Fprintf(w, "  Timestamp                 : %s\n", time.Unix(s.Dt, 0))
-	fmt.Fprintf(w, "  Sunrise                   : %s\n", time.Unix(int64(s.Sunrise), 0).In(tz).Format("15:04:05"))
-	fmt.Fprintf(w, "  Sunset                    : %s\n", time.Unix(int64(s.Sunset), 0).In(tz).Format("15:04:05"))
+func printCommonWeatherSummary(w io.Writer, s *openweathermap.CommonWeatherSummary, tempUnit, speedUnit string, tz *time.Location, lang openweathermap.Lang) {
+	fmt.Fprintf(w, "  Timestamp                 : %s\n", s.Time(tz))
+	if sunrise := s.SunriseTime(tz); !sunrise.IsZero() {
+		fmt.Fprintf(w, "  Sunrise                   : %s\n", sunrise.Format("15:04:05"))
+	}
+	if sunset := s.SunsetTime(tz); !sunset.IsZero() {
+		fmt.Fprintf(w, "  Sunset                    : %s\n", sunset.Format("15:04:05"))
+	}
 	fmt.Fprintf(w, "  Pressure                  : %d hPa\n", s.Pressure)
 	fmt.Fprintf(w, "  Humidity                  : %d%%\n", s.Humidity)
 	fmt.Fprintf(w, "  Dew point                 : %.02f%s\n", s.DewPoint, tempUnit)
@@ -131,7 +197,8 @@ func printCommonWeatherSummary(w io.Writer, s *openweathermap.CommonWeatherSumma
 	fmt.Fprintf(w, "  Clouds                    : %d%%\n", s.Clouds)
 	fmt.Fprintf(w, "  Visibility                : %dm\n", s.Visibility)
 	fmt.Fprintf(w, "  Wind speed                : %.02f %s\n", s.WindSpeed, speedUnit)
-	fmt.Fprintf(w, "  Wind direction            : %.02f degrees\n", s.WindSpeed)
+	dir := s.WindDirection()
+	fmt.Fprintf(w, "  Wind direction            : %s %s (%.0f degrees)\n", dir.Arrow(), dir.Abbrev(lang), dir.Degrees())
 	if s.WindGust != nil {
 		fmt.Fprintf(w, "  Wind gust                 : %.02f %s\n", *s.WindGust, speedUnit)
 	}
@@ -0,0 +1,257 @@
+package openweathermap
+
+import "fmt"
+
+// ConditionCode is a weather condition code, as returned in the `id` field of
+// the weather conditions. See
+// https://openweathermap.org/weather-conditions#Weather-Condition-Codes-2 .
+type ConditionCode int
+
+// weather condition codes.
+const (
+	// Group 2xx: thunderstorm
+	ThunderstormWithLightRain    ConditionCode = 200
+	ThunderstormWithRain         ConditionCode = 201
+	ThunderstormWithHeavyRain    ConditionCode = 202
+	LightThunderstorm            ConditionCode = 210
+	Thunderstorm                 ConditionCode = 211
+	HeavyThunderstorm            ConditionCode = 212
+	RaggedThunderstorm           ConditionCode = 221
+	ThunderstormWithLightDrizzle ConditionCode = 230
+	ThunderstormWithDrizzle      ConditionCode = 231
+	ThunderstormWithHeavyDrizzle ConditionCode = 232
+	// Group 3xx: drizzle
+	LightIntensityDrizzle     ConditionCode = 300
+	Drizzle                   ConditionCode = 301
+	HeavyIntensityDrizzle     ConditionCode = 302
+	LightIntensityDrizzleRain ConditionCode = 310
+	DrizzleRain               ConditionCode = 311
+	HeavyIntensityDrizzleRain ConditionCode = 312
+	ShowerRainAndDrizzle      ConditionCode = 313
+	HeavyShowerRainAndDrizzle ConditionCode = 314
+	ShowerDrizzle             ConditionCode = 321
+	// Group 5xx: rain
+	LightRain                ConditionCode = 500
+	ModerateRain             ConditionCode = 501
+	HeavyIntensityRain       ConditionCode = 502
+	VeryHeavyRain            ConditionCode = 503
+	ExtremeRain              ConditionCode = 504
+	FreezingRain             ConditionCode = 511
+	LightIntensityShowerRain ConditionCode = 520
+	ShowerRain               ConditionCode = 521
+	HeavyIntensityShowerRain ConditionCode = 522
+	RaggedShowerRain         ConditionCode = 531
+	// Group 6xx: snow
+	LightSnow        ConditionCode = 600
+	Snow             ConditionCode = 601
+	HeavySnow        ConditionCode = 602
+	Sleet            ConditionCode = 611
+	LightShowerSleet ConditionCode = 612
+	ShowerSleet      ConditionCode = 613
+	LightRainAndSnow ConditionCode = 615
+	RainAndSnow      ConditionCode = 616
+	LightShowerSnow  ConditionCode = 620
+	ShowerSnow       ConditionCode = 621
+	HeavyShowerSnow  ConditionCode = 622
+	// Group 7xx: atmosphere
+	Mist          ConditionCode = 701
+	Smoke         ConditionCode = 711
+	Haze          ConditionCode = 721
+	SandDustWhirl ConditionCode = 731
+	Fog           ConditionCode = 741
+	Sand          ConditionCode = 751
+	Dust          ConditionCode = 761
+	VolcanicAsh   ConditionCode = 762
+	Squalls       ConditionCode = 771
+	Tornado       ConditionCode = 781
+	// Group 800: clear
+	ClearSky ConditionCode = 800
+	// Group 80x: clouds
+	FewClouds       ConditionCode = 801
+	ScatteredClouds ConditionCode = 802
+	BrokenClouds    ConditionCode = 803
+	OvercastClouds  ConditionCode = 804
+)
+
+// conditions maps each known condition code to its English description and
+// to its icon code, without the day/night suffix.
+var conditions = map[ConditionCode]struct {
+	description string
+	icon        string
+}{
+	ThunderstormWithLightRain:    {"thunderstorm with light rain", "11"},
+	ThunderstormWithRain:         {"thunderstorm with rain", "11"},
+	ThunderstormWithHeavyRain:    {"thunderstorm with heavy rain", "11"},
+	LightThunderstorm:            {"light thunderstorm", "11"},
+	Thunderstorm:                 {"thunderstorm", "11"},
+	HeavyThunderstorm:            {"heavy thunderstorm", "11"},
+	RaggedThunderstorm:           {"ragged thunderstorm", "11"},
+	ThunderstormWithLightDrizzle: {"thunderstorm with light drizzle", "11"},
+	ThunderstormWithDrizzle:      {"thunderstorm with drizzle", "11"},
+	ThunderstormWithHeavyDrizzle: {"thunderstorm with heavy drizzle", "11"},
+	LightIntensityDrizzle:        {"light intensity drizzle", "09"},
+	Drizzle:                      {"drizzle", "09"},
+	HeavyIntensityDrizzle:        {"heavy intensity drizzle", "09"},
+	LightIntensityDrizzleRain:    {"light intensity drizzle rain", "09"},
+	DrizzleRain:                  {"drizzle rain", "09"},
+	HeavyIntensityDrizzleRain:    {"heavy intensity drizzle rain", "09"},
+	ShowerRainAndDrizzle:         {"shower rain and drizzle", "09"},
+	HeavyShowerRainAndDrizzle:    {"heavy shower rain and drizzle", "09"},
+	ShowerDrizzle:                {"shower drizzle", "09"},
+	LightRain:                    {"light rain", "10"},
+	ModerateRain:                 {"moderate rain", "10"},
+	HeavyIntensityRain:           {"heavy intensity rain", "10"},
+	VeryHeavyRain:                {"very heavy rain", "10"},
+	ExtremeRain:                  {"extreme rain", "10"},
+	FreezingRain:                 {"freezing rain", "13"},
+	LightIntensityShowerRain:     {"light intensity shower rain", "09"},
+	ShowerRain:                   {"shower rain", "09"},
+	HeavyIntensityShowerRain:     {"heavy intensity shower rain", "09"},
+	RaggedShowerRain:             {"ragged shower rain", "09"},
+	LightSnow:                    {"light snow", "13"},
+	Snow:                         {"snow", "13"},
+	HeavySnow:                    {"heavy snow", "13"},
+	Sleet:                        {"sleet", "13"},
+	LightShowerSleet:             {"light shower sleet", "13"},
+	ShowerSleet:                  {"shower sleet", "13"},
+	LightRainAndSnow:             {"light rain and snow", "13"},
+	RainAndSnow:                  {"rain and snow", "13"},
+	LightShowerSnow:              {"light shower snow", "13"},
+	ShowerSnow:                   {"shower snow", "13"},
+	HeavyShowerSnow:              {"heavy shower snow", "13"},
+	Mist:                         {"mist", "50"},
+	Smoke:                        {"smoke", "50"},
+	Haze:                         {"haze", "50"},
+	SandDustWhirl:                {"sand/dust whirls", "50"},
+	Fog:                          {"fog", "50"},
+	Sand:                         {"sand", "50"},
+	Dust:                         {"dust", "50"},
+	VolcanicAsh:                  {"volcanic ash", "50"},
+	Squalls:                      {"squalls", "50"},
+	Tornado:                      {"tornado", "50"},
+	ClearSky:                     {"clear sky", "01"},
+	FewClouds:                    {"few clouds", "02"},
+	ScatteredClouds:              {"scattered clouds", "03"},
+	BrokenClouds:                 {"broken clouds", "04"},
+	OvercastClouds:               {"overcast clouds", "04"},
+}
+
+// ConditionGroup is a group of weather conditions.
+type ConditionGroup int
+
+// weather condition groups.
+const (
+	UnknownGroup ConditionGroup = iota
+	ThunderstormGroup
+	DrizzleGroup
+	RainGroup
+	SnowGroup
+	AtmosphereGroup
+	ClearGroup
+	CloudsGroup
+)
+
+func (g ConditionGroup) String() string {
+	switch g {
+	case ThunderstormGroup:
+		return "Thunderstorm"
+	case DrizzleGroup:
+		return "Drizzle"
+	case RainGroup:
+		return "Rain"
+	case SnowGroup:
+		return "Snow"
+	case AtmosphereGroup:
+		return "Atmosphere"
+	case ClearGroup:
+		return "Clear"
+	case CloudsGroup:
+		return "Clouds"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(g))
+	}
+}
+
+// Group returns the group the condition belongs to.
+func (c ConditionCode) Group() ConditionGroup {
+	switch {
+	case c >= 200 && c < 300:
+		return ThunderstormGroup
+	case c >= 300 && c < 400:
+		return DrizzleGroup
+	case c >= 500 && c < 600:
+		return RainGroup
+	case c >= 600 && c < 700:
+		return SnowGroup
+	case c >= 700 && c < 800:
+		return AtmosphereGroup
+	case c == 800:
+		return ClearGroup
+	case c > 800 && c < 900:
+		return CloudsGroup
+	default:
+		return UnknownGroup
+	}
+}
+
+// IsKnown returns true if the condition is one of the documented ones.
+func (c ConditionCode) IsKnown() bool {
+	_, ok := conditions[c]
+	return ok
+}
+
+// IsPrecipitation returns true if the condition involves any kind of
+// precipitation, including thunderstorms with rain or drizzle.
+func (c ConditionCode) IsPrecipitation() bool {
+	switch c.Group() {
+	case DrizzleGroup, RainGroup, SnowGroup:
+		return true
+	case ThunderstormGroup:
+		return c != LightThunderstorm && c != Thunderstorm && c != HeavyThunderstorm && c != RaggedThunderstorm
+	default:
+		return false
+	}
+}
+
+// IsSevere returns true if the condition is potentially dangerous: all the
+// thunderstorms, and the most intense rain, snow and atmospheric conditions.
+func (c ConditionCode) IsSevere() bool {
+	switch c {
+	case VeryHeavyRain, ExtremeRain, FreezingRain,
+		HeavySnow, HeavyShowerSnow,
+		SandDustWhirl, VolcanicAsh, Squalls, Tornado:
+		return true
+	default:
+		return c.Group() == ThunderstormGroup
+	}
+}
+
+// Icon returns the code of the icon for the condition, e.g. "10d", as
+// returned by the API, that can be used to build the icon URL. The day flag
+// selects between the day and night variants. The icons package does not
+// store the night variants that are the same images as the day ones, e.g.
+// "09n", so use icons.Lookup rather than indexing icons.Icons. An empty
+// string is returned for unknown conditions.
+func (c ConditionCode) Icon(day bool) string {
+	cond, ok := conditions[c]
+	if !ok {
+		return ""
+	}
+	if day {
+		return cond.icon + "d"
+	}
+	return cond.icon + "n"
+}
+
+// Description returns the English description of the condition, as returned
+// by the API when no language is requested.
+func (c ConditionCode) Description() string {
+	if cond, ok := conditions[c]; ok {
+		return cond.description
+	}
+	return fmt.Sprintf("unknown(%d)", int(c))
+}
+
+func (c ConditionCode) String() string {
+	return c.Description()
+}
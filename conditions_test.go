@@ -0,0 +1,33 @@
+package openweathermap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/insomniacslk/openweathermap/icons"
+)
+
+func TestConditionIconLookup(t *testing.T) {
+	for code := range conditions {
+		dayIcon := code.Icon(true)
+		day, ok := icons.Lookup(dayIcon)
+		if !ok {
+			t.Errorf("%d: icon %q not found", code, dayIcon)
+		}
+		nightIcon := code.Icon(false)
+		if !strings.HasSuffix(nightIcon, "n") {
+			t.Errorf("%d: got night icon %q", code, nightIcon)
+		}
+		// the night variants of rain are different images: they must never
+		// be replaced by the day ones.
+		if strings.HasPrefix(nightIcon, "10") {
+			if night, ok := icons.Lookup(nightIcon); ok && bytes.Equal(night, day) {
+				t.Errorf("%d: night icon %q is the day image", code, nightIcon)
+			}
+		}
+	}
+	if got := LightRain.Icon(false); got != "10n" {
+		t.Errorf("LightRain.Icon(false): got %q, want %q", got, "10n")
+	}
+}
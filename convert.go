@@ -0,0 +1,130 @@
+package openweathermap
+
+// VisibilityUnits maps unit system to the unit used by ConvertVisibility.
+var VisibilityUnits = map[Units]string{
+	Standard: "m",
+	Metric:   "m",
+	Imperial: "mi",
+}
+
+// PrecipitationUnits maps unit system to the unit used by
+// ConvertPrecipitation.
+var PrecipitationUnits = map[Units]string{
+	Standard: "mm",
+	Metric:   "mm",
+	Imperial: "in",
+}
+
+// conversion factors.
+const (
+	metersPerMile        = 1609.344
+	millimetersPerInch   = 25.4
+	metersPerSecondToMPH = 3600 / metersPerMile
+	zeroCelsiusInKelvin  = 273.15
+)
+
+// ConvertTemp converts a temperature from one unit system to another. Empty
+// units are treated as Standard, like the API does.
+func ConvertTemp(t float64, from, to Units) float64 {
+	if from == to {
+		return t
+	}
+	// convert to Kelvin first.
+	switch from {
+	case Metric:
+		t += zeroCelsiusInKelvin
+	case Imperial:
+		t = (t-32)*5/9 + zeroCelsiusInKelvin
+	}
+	switch to {
+	case Metric:
+		return t - zeroCelsiusInKelvin
+	case Imperial:
+		return (t-zeroCelsiusInKelvin)*9/5 + 32
+	default:
+		return t
+	}
+}
+
+// ConvertSpeed converts a speed from one unit system to another, i.e.
+// between meters per second and miles per hour. Empty units are treated as
+// Standard, like the API does.
+func ConvertSpeed(v float64, from, to Units) float64 {
+	fromImperial, toImperial := from == Imperial, to == Imperial
+	switch {
+	case fromImperial && !toImperial:
+		return v / metersPerSecondToMPH
+	case !fromImperial && toImperial:
+		return v * metersPerSecondToMPH
+	default:
+		return v
+	}
+}
+
+// ConvertVisibility converts a visibility, that the API always returns in
+// meters, to the given unit system, i.e. to miles for Imperial.
+func ConvertVisibility(meters float64, to Units) float64 {
+	if to == Imperial {
+		return meters / metersPerMile
+	}
+	return meters
+}
+
+// ConvertPrecipitation converts a precipitation volume, that the API always
+// returns in millimeters, to the given unit system, i.e. to inches for
+// Imperial.
+func ConvertPrecipitation(mm float64, to Units) float64 {
+	if to == Imperial {
+		return mm / millimetersPerInch
+	}
+	return mm
+}
+
+// ConvertUnits converts in place all the temperatures and speeds in the
+// response from one unit system to another, e.g. to show a cached response
+// to users with different preferences. Visibility and precipitation are not
+// affected, see ConvertVisibility and ConvertPrecipitation.
+func (w *Weather) ConvertUnits(from, to Units) {
+	if w.Current != nil {
+		w.Current.convertUnits(from, to)
+	}
+	for idx := range w.Hourly {
+		w.Hourly[idx].convertUnits(from, to)
+	}
+	for idx := range w.Daily {
+		w.Daily[idx].convertUnits(from, to)
+	}
+}
+
+// ConvertUnits converts in place all the temperatures and speeds in the
+// response from one unit system to another. See Weather.ConvertUnits.
+func (t *TimeMachine) ConvertUnits(from, to Units) {
+	for idx := range t.Data {
+		t.Data[idx].convertUnits(from, to)
+	}
+}
+
+func (s *CommonWeatherSummary) convertUnits(from, to Units) {
+	s.DewPoint = ConvertTemp(s.DewPoint, from, to)
+	s.WindSpeed = ConvertSpeed(s.WindSpeed, from, to)
+	if s.WindGust != nil {
+		gust := ConvertSpeed(*s.WindGust, from, to)
+		s.WindGust = &gust
+	}
+}
+
+func (s *PointWeatherSummary) convertUnits(from, to Units) {
+	s.CommonWeatherSummary.convertUnits(from, to)
+	s.Temp = ConvertTemp(s.Temp, from, to)
+	s.FeelsLike = ConvertTemp(s.FeelsLike, from, to)
+}
+
+func (s *DailyWeatherSummary) convertUnits(from, to Units) {
+	s.CommonWeatherSummary.convertUnits(from, to)
+	for _, t := range []*float64{
+		&s.Temp.Day, &s.Temp.Min, &s.Temp.Max, &s.Temp.Night, &s.Temp.Eve, &s.Temp.Morn,
+		&s.FeelsLike.Day, &s.FeelsLike.Night, &s.FeelsLike.Eve, &s.FeelsLike.Morn,
+	} {
+		*t = ConvertTemp(*t, from, to)
+	}
+}
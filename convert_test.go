@@ -0,0 +1,142 @@
+package openweathermap
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestConvertTempSpeed(t *testing.T) {
+	// the same temperature and speed in each unit system.
+	temps := map[Units]float64{Standard: 293.15, Metric: 20, Imperial: 68, "": 293.15}
+	speeds := map[Units]float64{Standard: 10, Metric: 10, Imperial: 22.369363, "": 10}
+	for from := range temps {
+		for to := range temps {
+			if got, want := ConvertTemp(temps[from], from, to), temps[to]; math.Abs(got-want) > 1e-6 {
+				t.Errorf("ConvertTemp(%v, %q, %q): got %v, want %v", temps[from], from, to, got, want)
+			}
+			if got, want := ConvertSpeed(speeds[from], from, to), speeds[to]; math.Abs(got-want) > 1e-6 {
+				t.Errorf("ConvertSpeed(%v, %q, %q): got %v, want %v", speeds[from], from, to, got, want)
+			}
+		}
+	}
+	if got := ConvertTemp(-40, Metric, Imperial); math.Abs(got+40) > 1e-9 {
+		t.Errorf("ConvertTemp(-40, metric, imperial): got %v, want -40", got)
+	}
+	if got, want := ConvertVisibility(10000, Imperial), 6.2137119; math.Abs(got-want) > 1e-6 {
+		t.Errorf("ConvertVisibility(10000, imperial): got %v, want %v", got, want)
+	}
+	if got, want := ConvertPrecipitation(25.4, Imperial), 1.0; got != want {
+		t.Errorf("ConvertPrecipitation(25.4, imperial): got %v, want %v", got, want)
+	}
+	if got, want := ConvertPrecipitation(25.4, Metric), 25.4; got != want {
+		t.Errorf("ConvertPrecipitation(25.4, metric): got %v, want %v", got, want)
+	}
+}
+
+// weatherValues returns the temperatures and speeds of the response that
+// ConvertUnits converts.
+func weatherValues(w *Weather) []float64 {
+	var values []float64
+	common := func(s *CommonWeatherSummary) {
+		values = append(values, s.DewPoint, s.WindSpeed)
+		if s.WindGust != nil {
+			values = append(values, *s.WindGust)
+		}
+	}
+	common(&w.Current.CommonWeatherSummary)
+	values = append(values, w.Current.Temp, w.Current.FeelsLike)
+	for _, h := range w.Hourly {
+		common(&h.CommonWeatherSummary)
+		values = append(values, h.Temp, h.FeelsLike)
+	}
+	for _, d := range w.Daily {
+		common(&d.CommonWeatherSummary)
+		values = append(values,
+			d.Temp.Day, d.Temp.Min, d.Temp.Max, d.Temp.Night, d.Temp.Eve, d.Temp.Morn,
+			d.FeelsLike.Day, d.FeelsLike.Night, d.FeelsLike.Eve, d.FeelsLike.Morn)
+	}
+	return values
+}
+
+func TestWeatherConvertUnits(t *testing.T) {
+	// the sample response is in standard units.
+	w := loadWeather(t, "testdata/onecall.json")
+	original := weatherValues(w)
+
+	w.ConvertUnits(Standard, Imperial)
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"current temp", w.Current.Temp, 66.92},
+		{"current feels like", w.Current.FeelsLike, 67.496},
+		{"current dew point", w.Current.DewPoint, 63.572},
+		{"current wind speed", w.Current.WindSpeed, 7.0016},
+		{"current wind gust", *w.Current.WindGust, 15.0099},
+		{"hourly temp", w.Hourly[0].Temp, 65.948},
+		{"daily day temp", w.Daily[0].Temp.Day, 78.584},
+		{"daily min temp", w.Daily[0].Temp.Min, 63.572},
+		{"daily max temp", w.Daily[0].Temp.Max, 80.96},
+		{"daily night feels like", w.Daily[0].FeelsLike.Night, 64.796},
+		{"daily dew point", w.Daily[0].DewPoint, 63.194},
+		{"daily wind speed", w.Daily[0].WindSpeed, 8.9030},
+		{"daily wind gust", *w.Daily[0].WindGust, 19.9535},
+	} {
+		if math.Abs(tc.got-tc.want) > 0.001 {
+			t.Errorf("imperial %s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	w.ConvertUnits(Imperial, Metric)
+	if got, want := w.Current.Temp, 19.4; math.Abs(got-want) > 1e-9 {
+		t.Errorf("metric current temp: got %v, want %v", got, want)
+	}
+	if got, want := w.Current.WindSpeed, 3.13; math.Abs(got-want) > 1e-9 {
+		t.Errorf("metric current wind speed: got %v, want %v", got, want)
+	}
+
+	// and back to the original values.
+	w.ConvertUnits(Metric, Standard)
+	got := weatherValues(w)
+	for i := range original {
+		if math.Abs(got[i]-original[i]) > 1e-9 {
+			t.Errorf("round trip value %d: got %v, want %v", i, got[i], original[i])
+		}
+	}
+}
+
+func TestTimeMachineConvertUnits(t *testing.T) {
+	var tm TimeMachine
+	data := `{"data":[{"dt":1704067200,"temp":283.15,"feels_like":281.15,"dew_point":273.15,"wind_speed":5,"wind_gust":10}]}`
+	if err := json.Unmarshal([]byte(data), &tm); err != nil {
+		t.Fatal(err)
+	}
+	gust := tm.Data[0].WindGust
+	tm.ConvertUnits(Standard, Metric)
+	d := tm.Data[0]
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"temp", d.Temp, 10},
+		{"feels like", d.FeelsLike, 8},
+		{"dew point", d.DewPoint, 0},
+		{"wind speed", d.WindSpeed, 5},
+		{"wind gust", *d.WindGust, 10},
+	} {
+		if math.Abs(tc.got-tc.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	tm.ConvertUnits(Metric, Imperial)
+	if got, want := tm.Data[0].Temp, 50.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("imperial temp: got %v, want %v", got, want)
+	}
+	if got, want := *tm.Data[0].WindGust, 22.369363; math.Abs(got-want) > 1e-6 {
+		t.Errorf("imperial wind gust: got %v, want %v", got, want)
+	}
+	if *gust != 10 {
+		t.Errorf("the original wind gust was modified: %v", *gust)
+	}
+}
@@ -0,0 +1,130 @@
+// Package current implements OpenWeatherMap's current weather data API 2.5
+// described at https://openweathermap.org/current . Unlike the One Call API
+// 3.0, it is available with the free subscription.
+package current
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/insomniacslk/openweathermap"
+	"github.com/insomniacslk/openweathermap/internal/apiutil"
+)
+
+// Response represents a current weather data response.
+type Response struct {
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	Weather []struct {
+		ID          openweathermap.ConditionCode `json:"id"`
+		Main        string                       `json:"main"`
+		Description string                       `json:"description"`
+		Icon        string                       `json:"icon"`
+	} `json:"weather"`
+	Base string              `json:"base"`
+	Main openweathermap.Main `json:"main"`
+	// Visibility is in meters, up to 10 km.
+	Visibility int                 `json:"visibility"`
+	Wind       openweathermap.Wind `json:"wind"`
+	Clouds     struct {
+		All int `json:"all"`
+	} `json:"clouds"`
+	// Rain and Snow are the precipitation volumes in mm for the last one
+	// and three hours, if any.
+	Rain struct {
+		OneHour    *float64 `json:"1h"`
+		ThreeHours *float64 `json:"3h"`
+	} `json:"rain"`
+	Snow struct {
+		OneHour    *float64 `json:"1h"`
+		ThreeHours *float64 `json:"3h"`
+	} `json:"snow"`
+	Dt  int64 `json:"dt"`
+	Sys struct {
+		Country string `json:"country"`
+		Sunrise int64  `json:"sunrise"`
+		Sunset  int64  `json:"sunset"`
+	} `json:"sys"`
+	// Timezone is the shift in seconds from UTC.
+	Timezone int    `json:"timezone"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Cod      int    `json:"cod"`
+}
+
+// Location returns the time zone of the requested location. The API only
+// reports its offset from UTC, so the returned zone does not follow
+// daylight saving time changes.
+func (r *Response) Location() *time.Location {
+	return time.FixedZone("", r.Timezone)
+}
+
+// Time returns the time of the data calculation in the requested location's
+// time zone, or the zero time if it is not reported.
+func (r *Response) Time() time.Time {
+	return apiutil.UnixTime(r.Dt, r.Location())
+}
+
+// SunriseTime returns the sunrise time in the requested location's time
+// zone, or the zero time if the sun does not rise on that day.
+func (r *Response) SunriseTime() time.Time {
+	return apiutil.UnixTime(r.Sys.Sunrise, r.Location())
+}
+
+// SunsetTime returns the sunset time in the requested location's time zone,
+// or the zero time if the sun does not set on that day.
+func (r *Response) SunsetTime() time.Time {
+	return apiutil.UnixTime(r.Sys.Sunset, r.Location())
+}
+
+// ConvertUnits converts the temperatures and the wind speeds of the
+// response in place from one unit system to another.
+func (r *Response) ConvertUnits(from, to openweathermap.Units) {
+	r.Main.ConvertUnits(from, to)
+	r.Wind.ConvertUnits(from, to)
+}
+
+// Client is a current weather data API client.
+type Client struct {
+	owm *openweathermap.Client
+}
+
+// NewClient returns a current weather data API client that executes its
+// requests with the given OpenWeatherMap client.
+func NewClient(c *openweathermap.Client) *Client {
+	return &Client{owm: c}
+}
+
+// Request executes a current weather data request for the location selected
+// by query, e.g. openweathermap.ByCityName("London,uk"). If units or lang are
+// empty, the client's defaults are used.
+func (c *Client) Request(query openweathermap.Query, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	return c.RequestContext(context.Background(), query, units, lang)
+}
+
+// RequestContext is like Request, but the request is bound to the given
+// context.
+func (c *Client) RequestContext(ctx context.Context, query openweathermap.Query, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	u, err := c.owm.URL(openweathermap.DataAPI, "/weather")
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	query.Set(q)
+	apiutil.SetUnitsLang(q, units, lang, c.owm.Units, c.owm.Lang)
+	u.RawQuery = q.Encode()
+
+	body, err := c.owm.GetContext(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	var apiResp Response
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	return &apiResp, nil
+}
@@ -0,0 +1,47 @@
+package openweathermap
+
+// Main is the "main" object of the data API 2.5 responses, shared by the
+// current, forecast and find packages.
+type Main struct {
+	Temp      float64 `json:"temp"`
+	FeelsLike float64 `json:"feels_like"`
+	TempMin   float64 `json:"temp_min"`
+	TempMax   float64 `json:"temp_max"`
+	// Pressure is in hPa. Some requests report it with decimals.
+	Pressure  float64 `json:"pressure"`
+	Humidity  int     `json:"humidity"`
+	SeaLevel  float64 `json:"sea_level"`
+	GrndLevel float64 `json:"grnd_level"`
+	// TempKf is an internal parameter of the forecast.
+	TempKf float64 `json:"temp_kf"`
+}
+
+// ConvertUnits converts the temperatures in place from one unit system to
+// another.
+func (m *Main) ConvertUnits(from, to Units) {
+	for _, t := range []*float64{&m.Temp, &m.FeelsLike, &m.TempMin, &m.TempMax} {
+		*t = ConvertTemp(*t, from, to)
+	}
+}
+
+// Wind is the "wind" object of the data API 2.5 responses, shared by the
+// current, forecast and find packages.
+type Wind struct {
+	Speed float64  `json:"speed"`
+	Deg   float64  `json:"deg"`
+	Gust  *float64 `json:"gust"`
+}
+
+// ConvertUnits converts the speeds in place from one unit system to another.
+func (w *Wind) ConvertUnits(from, to Units) {
+	w.Speed = ConvertSpeed(w.Speed, from, to)
+	if w.Gust != nil {
+		gust := ConvertSpeed(*w.Gust, from, to)
+		w.Gust = &gust
+	}
+}
+
+// Direction returns the wind direction.
+func (w *Wind) Direction() WindDirection {
+	return WindDirection(w.Deg)
+}
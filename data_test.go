@@ -0,0 +1,34 @@
+package openweathermap
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMainWindConvertUnits(t *testing.T) {
+	gust := 10.0
+	m := Main{Temp: 20, FeelsLike: 19, TempMin: 15, TempMax: 25, Pressure: 1013}
+	w := Wind{Speed: 5, Deg: 90, Gust: &gust}
+	m.ConvertUnits(Metric, Imperial)
+	w.ConvertUnits(Metric, Imperial)
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"temp", m.Temp, 68},
+		{"feels_like", m.FeelsLike, 66.2},
+		{"temp_min", m.TempMin, 59},
+		{"temp_max", m.TempMax, 77},
+		{"pressure", m.Pressure, 1013},
+		{"speed", w.Speed, 11.18},
+		{"gust", *w.Gust, 22.37},
+		{"deg", w.Deg, 90},
+	} {
+		if math.Abs(tc.got-tc.want) > 0.01 {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	if gust != 10 {
+		t.Errorf("the original gust value was modified: %v", gust)
+	}
+}
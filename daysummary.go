@@ -0,0 +1,96 @@
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DaySummary maps to a JSON response from the One Call API's day_summary
+// endpoint, that returns aggregated weather data for a single day. Values
+// labeled afternoon, night, evening and morning refer respectively to 12:00,
+// 00:00, 18:00 and 06:00 local time.
+type DaySummary struct {
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+	Tz         string  `json:"tz"`
+	Date       string  `json:"date"`
+	Units      Units   `json:"units"`
+	CloudCover struct {
+		Afternoon float64 `json:"afternoon"`
+	} `json:"cloud_cover"`
+	Humidity struct {
+		Afternoon float64 `json:"afternoon"`
+	} `json:"humidity"`
+	Precipitation struct {
+		Total float64 `json:"total"`
+	} `json:"precipitation"`
+	Temperature struct {
+		Min       float64 `json:"min"`
+		Max       float64 `json:"max"`
+		Afternoon float64 `json:"afternoon"`
+		Night     float64 `json:"night"`
+		Evening   float64 `json:"evening"`
+		Morning   float64 `json:"morning"`
+	} `json:"temperature"`
+	Pressure struct {
+		Afternoon float64 `json:"afternoon"`
+	} `json:"pressure"`
+	Wind struct {
+		Max struct {
+			Speed     float64 `json:"speed"`
+			Direction float64 `json:"direction"`
+		} `json:"max"`
+	} `json:"wind"`
+}
+
+// DaySummary returns the aggregated weather data for the given date, from
+// 2nd January 1979 up to a year and a half ahead, see
+// https://openweathermap.org/api/one-call-3#history_daily_aggregation . Only
+// the year, month and day of date are used. If tzOffset is nil, the server
+// picks the time zone from the coordinates, otherwise it uses the given
+// offset from UTC. If units or lang are empty, the client's defaults are
+// used.
+func (c *Client) DaySummary(lat, lon float64, date time.Time, tzOffset *time.Duration, units Units, lang Lang) (*DaySummary, error) {
+	return c.DaySummaryContext(context.Background(), lat, lon, date, tzOffset, units, lang)
+}
+
+// DaySummaryContext is like DaySummary, but the request is bound to the
+// given context.
+func (c *Client) DaySummaryContext(ctx context.Context, lat, lon float64, date time.Time, tzOffset *time.Duration, units Units, lang Lang) (*DaySummary, error) {
+	u, err := c.URL(OneCallAPI, "/day_summary")
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	setCoordinates(q, lat, lon)
+	q.Set("date", date.Format("2006-01-02"))
+	if tzOffset != nil {
+		q.Set("tz", formatOffset(*tzOffset))
+	}
+	c.setUnitsLang(q, units, lang)
+	u.RawQuery = q.Encode()
+
+	body, err := c.GetContext(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	var apiResp DaySummary
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	return &apiResp, nil
+}
+
+// formatOffset formats an offset from UTC as expected by the API, e.g.
+// "+05:30" or "-03:00".
+func formatOffset(offset time.Duration) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	minutes := int(offset / time.Minute)
+	return fmt.Sprintf("%c%02d:%02d", sign, minutes/60, minutes%60)
+}
@@ -0,0 +1,152 @@
+package openweathermap
+
+import (
+	"fmt"
+	"math"
+)
+
+// HeatIndex returns the apparent temperature in °C caused by the combined
+// effect of temperature and relative humidity, using the NOAA National
+// Weather Service regression by Rothfusz and its adjustments, see
+// https://www.wpc.ncep.noaa.gov/html/heatindex_equation.shtml .
+func HeatIndex(tempC, relHumidity float64) float64 {
+	t := ConvertTemp(tempC, Metric, Imperial)
+	rh := relHumidity
+	// the simple formula is used when its result is below 80°F.
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+			0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+			0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		if rh < 13 && t >= 80 && t <= 112 {
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		} else if rh > 85 && t >= 80 && t <= 87 {
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return ConvertTemp(hi, Imperial, Metric)
+}
+
+// WindChill returns the wind chill index in °C, using the formula adopted
+// in 2001 by Environment Canada and the NOAA National Weather Service, see
+// https://www.canada.ca/en/environment-climate-change/services/weather-health/wind-chill-cold-weather/wind-chill-index.html .
+// The index is only defined for temperatures up to 10°C and wind speeds
+// above 4.8 km/h; otherwise the air temperature is returned.
+func WindChill(tempC, windSpeedMS float64) float64 {
+	v := windSpeedMS * 3.6 // km/h
+	if tempC > 10 || v <= 4.8 {
+		return tempC
+	}
+	v016 := math.Pow(v, 0.16)
+	return 13.12 + 0.6215*tempC - 11.37*v016 + 0.3965*tempC*v016
+}
+
+// Humidex returns the humidex in °C, the index used by Environment Canada
+// to describe how hot humid weather feels, from the temperature and the dew
+// point.
+func Humidex(tempC, dewPointC float64) float64 {
+	dewPointK := dewPointC + zeroCelsiusInKelvin
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/dewPointK))
+	return tempC + 0.5555*(e-10)
+}
+
+// WetBulbTemp returns the wet-bulb temperature in °C, from the temperature
+// and the relative humidity at sea level pressure, using Stull's 2011
+// empirical formula. It is accurate within 1°C for relative humidity between
+// 5% and 99% and temperature between -20°C and 50°C.
+func WetBulbTemp(tempC, relHumidity float64) float64 {
+	t, rh := tempC, relHumidity
+	return t*math.Atan(0.151977*math.Sqrt(rh+8.313659)) +
+		math.Atan(t+rh) - math.Atan(rh-1.676331) +
+		0.00391838*math.Pow(rh, 1.5)*math.Atan(0.023101*rh) -
+		4.686035
+}
+
+// AbsoluteHumidity returns the mass of water vapour in g/m³, from the
+// temperature and the relative humidity.
+func AbsoluteHumidity(tempC, relHumidity float64) float64 {
+	// saturation vapour pressure in hPa, by the Magnus formula.
+	es := 6.112 * math.Exp(17.67*tempC/(tempC+243.5))
+	return es * relHumidity * 2.1674 / (tempC + zeroCelsiusInKelvin)
+}
+
+// Beaufort is a wind force on the Beaufort scale, from 0 to 12.
+type Beaufort int
+
+// upper wind speed limits in m/s of the Beaufort scale forces from 0 to 11.
+var beaufortLimits = []float64{0.5, 1.6, 3.4, 5.5, 8.0, 10.8, 13.9, 17.2, 20.8, 24.5, 28.5, 32.7}
+
+var beaufortNames = []string{
+	"calm",
+	"light air",
+	"light breeze",
+	"gentle breeze",
+	"moderate breeze",
+	"fresh breeze",
+	"strong breeze",
+	"near gale",
+	"gale",
+	"strong gale",
+	"storm",
+	"violent storm",
+	"hurricane force",
+}
+
+// BeaufortScale returns the Beaufort force for the given wind speed in m/s.
+func BeaufortScale(windSpeedMS float64) Beaufort {
+	for force, limit := range beaufortLimits {
+		if windSpeedMS < limit {
+			return Beaufort(force)
+		}
+	}
+	return Beaufort(len(beaufortLimits))
+}
+
+func (b Beaufort) String() string {
+	if b < 0 || int(b) >= len(beaufortNames) {
+		return fmt.Sprintf("unknown(%d)", int(b))
+	}
+	return beaufortNames[b]
+}
+
+// Beaufort returns the wind force on the Beaufort scale. The units are the
+// ones the summary is expressed in.
+func (s *CommonWeatherSummary) Beaufort(units Units) Beaufort {
+	return BeaufortScale(ConvertSpeed(s.WindSpeed, units, Metric))
+}
+
+// AbsoluteHumidity returns the mass of water vapour in g/m³. The units are
+// the ones the summary is expressed in.
+func (s *PointWeatherSummary) AbsoluteHumidity(units Units) float64 {
+	return AbsoluteHumidity(ConvertTemp(s.Temp, units, Metric), float64(s.Humidity))
+}
+
+// HeatIndex returns the heat index, see the HeatIndex function. The units
+// are the ones the summary is expressed in, and are used for the result too.
+func (s *PointWeatherSummary) HeatIndex(units Units) float64 {
+	hi := HeatIndex(ConvertTemp(s.Temp, units, Metric), float64(s.Humidity))
+	return ConvertTemp(hi, Metric, units)
+}
+
+// WindChill returns the wind chill index, see the WindChill function. The
+// units are the ones the summary is expressed in, and are used for the
+// result too.
+func (s *PointWeatherSummary) WindChill(units Units) float64 {
+	wc := WindChill(ConvertTemp(s.Temp, units, Metric), ConvertSpeed(s.WindSpeed, units, Metric))
+	return ConvertTemp(wc, Metric, units)
+}
+
+// Humidex returns the humidex, see the Humidex function. The units are the
+// ones the summary is expressed in, and are used for the result too.
+func (s *PointWeatherSummary) Humidex(units Units) float64 {
+	h := Humidex(ConvertTemp(s.Temp, units, Metric), ConvertTemp(s.DewPoint, units, Metric))
+	return ConvertTemp(h, Metric, units)
+}
+
+// WetBulbTemp returns the wet-bulb temperature, see the WetBulbTemp
+// function. The units are the ones the summary is expressed in, and are used
+// for the result too.
+func (s *PointWeatherSummary) WetBulbTemp(units Units) float64 {
+	wb := WetBulbTemp(ConvertTemp(s.Temp, units, Metric), float64(s.Humidity))
+	return ConvertTemp(wb, Metric, units)
+}
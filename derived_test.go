@@ -0,0 +1,168 @@
+package openweathermap
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHeatIndex checks the heat index against the NOAA National Weather
+// Service heat index chart, in °F.
+func TestHeatIndex(t *testing.T) {
+	for _, tc := range []struct {
+		tempF, rh, want float64
+	}{
+		{80, 40, 80},
+		{86, 40, 85},
+		{90, 40, 91},
+		{96, 40, 101},
+		{100, 40, 109},
+		{80, 60, 82},
+		{84, 60, 88},
+		{90, 60, 100},
+		{94, 60, 110},
+		{100, 60, 129},
+		{80, 80, 84},
+		{86, 80, 100},
+		{90, 80, 113},
+		{96, 80, 138},
+	} {
+		hi := HeatIndex(ConvertTemp(tc.tempF, Imperial, Metric), tc.rh)
+		if got := math.Round(ConvertTemp(hi, Metric, Imperial)); got != tc.want {
+			t.Errorf("HeatIndex(%v°F, %v%%): got %v°F, want %v°F", tc.tempF, tc.rh, got, tc.want)
+		}
+	}
+}
+
+// TestWindChill checks the wind chill index against the Environment Canada
+// wind chill calculation chart, in °C and km/h.
+func TestWindChill(t *testing.T) {
+	for _, tc := range []struct {
+		tempC, windKMH, want float64
+	}{
+		{0, 10, -3},
+		{5, 40, -1},
+		{-10, 20, -18},
+		{-15, 5, -19},
+		{-20, 30, -33},
+		{-25, 25, -38},
+		{-30, 50, -49},
+		{-40, 60, -64},
+		// outside of the range where the index is defined.
+		{15, 30, 15},
+		{-10, 4, -10},
+	} {
+		if got := math.Round(WindChill(tc.tempC, tc.windKMH/3.6)); got != tc.want {
+			t.Errorf("WindChill(%v°C, %v km/h): got %v°C, want %v°C", tc.tempC, tc.windKMH, got, tc.want)
+		}
+	}
+}
+
+// TestHumidex checks the humidex against the Environment Canada humidex
+// table, by temperature and dew point in °C.
+func TestHumidex(t *testing.T) {
+	for _, tc := range []struct {
+		tempC, dewPointC, want float64
+	}{
+		{20, 10, 21},
+		{25, 15, 29},
+		{30, 15, 34},
+		{30, 20, 38},
+		{35, 25, 47},
+		{40, 20, 48},
+	} {
+		if got := math.Round(Humidex(tc.tempC, tc.dewPointC)); got != tc.want {
+			t.Errorf("Humidex(%v°C, %v°C): got %v, want %v", tc.tempC, tc.dewPointC, got, tc.want)
+		}
+	}
+}
+
+func TestWetBulbTemp(t *testing.T) {
+	// example from Stull's paper.
+	if got := WetBulbTemp(20, 50); math.Abs(got-13.7) > 0.05 {
+		t.Errorf("WetBulbTemp(20°C, 50%%): got %v°C, want 13.7°C", got)
+	}
+}
+
+func TestAbsoluteHumidity(t *testing.T) {
+	if got := AbsoluteHumidity(20, 50); math.Abs(got-8.64) > 0.05 {
+		t.Errorf("AbsoluteHumidity(20°C, 50%%): got %v g/m³, want 8.64 g/m³", got)
+	}
+}
+
+func TestBeaufortScale(t *testing.T) {
+	for _, tc := range []struct {
+		speed float64
+		want  Beaufort
+	}{
+		{0, 0},
+		{0.49, 0},
+		{0.5, 1},
+		{1.59, 1},
+		{1.6, 2},
+		{5.5, 4},
+		{10.79, 5},
+		{10.8, 6},
+		{24.5, 10},
+		{32.69, 11},
+		{32.7, 12},
+		{60, 12},
+	} {
+		if got := BeaufortScale(tc.speed); got != tc.want {
+			t.Errorf("BeaufortScale(%v m/s): got %d, want %d", tc.speed, got, tc.want)
+		}
+	}
+	if got := Beaufort(12).String(); got != "hurricane force" {
+		t.Errorf("Beaufort(12).String(): got %q, want %q", got, "hurricane force")
+	}
+	if got := Beaufort(13).String(); got != "unknown(13)" {
+		t.Errorf("Beaufort(13).String(): got %q, want %q", got, "unknown(13)")
+	}
+}
+
+// TestSummaryDerived checks that the methods of PointWeatherSummary take and
+// return values in the units of the summary.
+func TestSummaryDerived(t *testing.T) {
+	for _, units := range []Units{Standard, Metric, Imperial} {
+		// 90°F and 60% relative humidity give a heat index of about 100°F.
+		var hot PointWeatherSummary
+		hot.Temp = ConvertTemp(90, Imperial, units)
+		hot.Humidity = 60
+		wantHI := ConvertTemp(HeatIndex(ConvertTemp(90, Imperial, Metric), 60), Metric, units)
+		if got := hot.HeatIndex(units); math.Abs(got-wantHI) > 1e-9 {
+			t.Errorf("%s: HeatIndex: got %v, want %v", units, got, wantHI)
+		}
+		if got := math.Round(ConvertTemp(hot.HeatIndex(units), units, Imperial)); got != 100 {
+			t.Errorf("%s: HeatIndex: got %v°F, want 100°F", units, got)
+		}
+
+		// -10°C with a 20 km/h wind give a wind chill of -17.9°C, and a
+		// Beaufort force of 4.
+		var cold PointWeatherSummary
+		cold.Temp = ConvertTemp(-10, Metric, units)
+		cold.WindSpeed = ConvertSpeed(20/3.6, Metric, units)
+		if got := ConvertTemp(cold.WindChill(units), units, Metric); math.Abs(got-(-17.86)) > 0.01 {
+			t.Errorf("%s: WindChill: got %v°C, want -17.86°C", units, got)
+		}
+		if got := cold.Beaufort(units); got != 4 {
+			t.Errorf("%s: Beaufort: got %d, want 4", units, got)
+		}
+
+		// 30°C with a dew point of 15°C give a humidex of 34.
+		var humid PointWeatherSummary
+		humid.Temp = ConvertTemp(30, Metric, units)
+		humid.DewPoint = ConvertTemp(15, Metric, units)
+		if got := math.Round(ConvertTemp(humid.Humidex(units), units, Metric)); got != 34 {
+			t.Errorf("%s: Humidex: got %v, want 34", units, got)
+		}
+
+		var mild PointWeatherSummary
+		mild.Temp = ConvertTemp(20, Metric, units)
+		mild.Humidity = 50
+		if got := ConvertTemp(mild.WetBulbTemp(units), units, Metric); math.Abs(got-13.7) > 0.05 {
+			t.Errorf("%s: WetBulbTemp: got %v°C, want 13.7°C", units, got)
+		}
+		if got := mild.AbsoluteHumidity(units); math.Abs(got-8.64) > 0.05 {
+			t.Errorf("%s: AbsoluteHumidity: got %v g/m³, want 8.64 g/m³", units, got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package openweathermap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Errors that can be matched with errors.Is against the errors returned by
+// the API calls, to tell the kind of failure.
+var (
+	ErrInvalidKey           = errors.New("invalid API key")
+	ErrRateLimited          = errors.New("rate limited")
+	ErrNotFound             = errors.New("not found")
+	ErrSubscriptionRequired = errors.New("subscription required")
+	// ErrQuotaExceeded is returned when the client's own RateLimiter refuses
+	// a call, before it reaches the API.
+	ErrQuotaExceeded = errors.New("client-side quota exceeded")
+)
+
+// APIError is the error returned when an API call has failed, by this
+// package and by all the API packages. Use errors.As to access it, or
+// errors.Is with one of the Err* values to check the kind of failure.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Cod is the code returned by OpenWeatherMap in the response body. If
+	// the body does not contain a valid code, it is set to StatusCode.
+	Cod int
+	// Message is the error message returned by OpenWeatherMap, or the HTTP
+	// status if the body does not contain one.
+	Message string
+	// Endpoint is the URL that was requested, without its query string so
+	// that the app ID is not leaked.
+	Endpoint string
+	// Body is the raw response body.
+	Body []byte
+	// RetryAfter is the time to wait before retrying as requested by the
+	// server via the Retry-After header, or zero if not specified.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request to %s failed with %d: %s", e.Endpoint, e.Cod, e.Message)
+}
+
+// Is reports whether the error is of the kind represented by target. It
+// allows to use errors.Is with ErrInvalidKey, ErrRateLimited, ErrNotFound and
+// ErrSubscriptionRequired.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidKey:
+		return e.StatusCode == http.StatusUnauthorized && !e.isSubscriptionError()
+	case ErrSubscriptionRequired:
+		return e.isSubscriptionError()
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	default:
+		return false
+	}
+}
+
+// isSubscriptionError returns true if the API refused the call because the
+// key is valid, but not subscribed to the requested API. OpenWeatherMap uses
+// 401 for this case too, so the message has to be inspected.
+func (e *APIError) isSubscriptionError() bool {
+	if e.StatusCode != http.StatusUnauthorized && e.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return strings.Contains(strings.ToLower(e.Message), "subscription")
+}
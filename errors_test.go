@@ -0,0 +1,80 @@
+package openweathermap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	sentinels := []error{ErrInvalidKey, ErrSubscriptionRequired, ErrRateLimited, ErrNotFound, ErrQuotaExceeded}
+	for _, tc := range []struct {
+		status  int
+		body    string
+		cod     int
+		message string
+		want    error
+	}{
+		{http.StatusUnauthorized, `{"cod":401,"message":"Invalid API key. Please see https://openweathermap.org/faq#error401 for more info."}`,
+			401, "Invalid API key. Please see https://openweathermap.org/faq#error401 for more info.", ErrInvalidKey},
+		{http.StatusUnauthorized, `{"cod":401,"message":"Please note that using One Call 3.0 requires a separate subscription to the One Call by Call plan."}`,
+			401, "Please note that using One Call 3.0 requires a separate subscription to the One Call by Call plan.", ErrSubscriptionRequired},
+		{http.StatusForbidden, `{"cod":403,"message":"Subscription required"}`,
+			403, "Subscription required", ErrSubscriptionRequired},
+		{http.StatusNotFound, `{"cod":"404","message":"city not found"}`,
+			404, "city not found", ErrNotFound},
+		{http.StatusTooManyRequests, `{"cod":429,"message":"Your account is temporary blocked"}`,
+			429, "Your account is temporary blocked", ErrRateLimited},
+		{http.StatusBadRequest, `{"cod":"400","message":"wrong latitude"}`,
+			400, "wrong latitude", nil},
+		// a body that is not JSON falls back to the HTTP status.
+		{http.StatusBadGateway, `<html>Bad Gateway</html>`,
+			502, "502 Bad Gateway", nil},
+		// as does a code that is not a number.
+		{http.StatusInternalServerError, `{"cod":"oops"}`,
+			500, "500 Internal Server Error", nil},
+	} {
+		resp := &http.Response{
+			StatusCode: tc.status,
+			Status:     fmt.Sprintf("%d %s", tc.status, http.StatusText(tc.status)),
+			Header:     http.Header{},
+		}
+		u, _ := url.Parse("https://api.openweathermap.org/data/2.5/weather?q=x&appid=SECRET")
+		var err error = newAPIError(resp, u, []byte(tc.body))
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%d: got %T, want an *APIError", tc.status, err)
+		}
+		if apiErr.StatusCode != tc.status || apiErr.Cod != tc.cod || apiErr.Message != tc.message {
+			t.Errorf("%d: got status %d, cod %d, message %q, want %d, %d, %q",
+				tc.status, apiErr.StatusCode, apiErr.Cod, apiErr.Message, tc.status, tc.cod, tc.message)
+		}
+		if want := "https://api.openweathermap.org/data/2.5/weather"; apiErr.Endpoint != want {
+			t.Errorf("%d: got endpoint %s, want %s", tc.status, apiErr.Endpoint, want)
+		}
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == tc.want) {
+				t.Errorf("%d %q: errors.Is(%v) = %v", tc.status, tc.message, sentinel, got)
+			}
+		}
+		// the sentinels also match through wrapping.
+		if tc.want != nil && !errors.Is(fmt.Errorf("wrapped: %w", err), tc.want) {
+			t.Errorf("%d: the wrapped error does not match %v", tc.status, tc.want)
+		}
+	}
+}
+
+func TestAPIErrorRetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Header:     http.Header{"Retry-After": {"30"}},
+	}
+	u, _ := url.Parse("https://api.openweathermap.org/data/3.0/onecall")
+	if got := newAPIError(resp, u, nil).RetryAfter; got != 30*time.Second {
+		t.Errorf("got RetryAfter %s, want 30s", got)
+	}
+}
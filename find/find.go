@@ -1,109 +1,279 @@
-// Package find implements OpenWeatherMap's find API which seems to be part of
-// the data API, but the find method is not documented, see
-// https://openweathermap.org/current.
+// Package find implements the methods of OpenWeatherMap's data API that
+// return the current weather in multiple cities: the cities in a circle, in
+// a bounding box, or with the given IDs, see
+// https://openweathermap.org/current#cycle , and the search by name, which
+// is not documented. The current weather in a single city is implemented by
+// the current package.
 package find
 
-// TODO this should implement the entire data API, plus the undocumented find
-// method.
-
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/insomniacslk/openweathermap"
+	"github.com/insomniacslk/openweathermap/internal/apiutil"
 )
 
-var baseURL = url.URL{
-	Scheme: "https",
-	Host:   "api.openweathermap.org",
-	Path:   "/data/2.5/",
-}
-
 // Response represents a data find response.
 type Response struct {
 	Cod     string `json:"cod"`
 	Message string `json:"message"`
 	Count   int    `json:"count"`
-	List    []struct {
-		ID    int    `json:"id"`
-		Name  string `json:"name"`
-		Coord struct {
-			Lat float64 `json:"lat"`
-			Lon float64 `json:"lon"`
-		} `json:"coord"`
-		Main struct {
-			Temp      float64 `json:"temp"`
-			FeelsLike float64 `json:"feels_like"`
-			TempMin   float64 `json:"temp_min"`
-			TempMax   float64 `json:"temp_max"`
-			Pressure  int     `json:"pressure"`
-			Humidity  int     `json:"humidity"`
-		} `json:"main"`
-		Dt   int `json:"dt"`
-		Wind struct {
-			Speed float64 `json:"speed"`
-			Deg   int     `json:"deg"`
-		} `json:"wind"`
-		Sys struct {
-			Country string `json:"country"`
-		} `json:"sys"`
-		// Rain undefined for now
-		// Snow undefined for now
-		Weather []struct {
-			ID          int    `json:"id"`
-			Main        string `json:"main"`
-			Description string `json:"description"`
-			Icon        string `json:"icon"`
-		} `json:"weather"`
-	} `json:"list"`
-	Name       string            `json:"name"`
-	LocalNames map[string]string `json:"local_names"`
-	Lat        float64           `json:"lat"`
-	Lon        float64           `json:"lon"`
-	Country    string            `json:"country"`
-	State      string            `json:"state"`
+	List    []Item `json:"list"`
+}
+
+// GroupResponse represents the response to a group or bounding box request.
+type GroupResponse struct {
+	// Cnt is the number of items in List.
+	Cnt  int    `json:"cnt"`
+	List []Item `json:"list"`
+}
+
+// MaxGroupIDs is the maximum number of city IDs in a group request.
+const MaxGroupIDs = 20
+
+// BoundingBox is a rectangle of coordinates, with the zoom level of the map
+// that determines how many cities are returned.
+type BoundingBox struct {
+	LonLeft   float64
+	LatBottom float64
+	LonRight  float64
+	LatTop    float64
+	Zoom      int
+}
+
+func (b BoundingBox) String() string {
+	coords := []float64{b.LonLeft, b.LatBottom, b.LonRight, b.LatTop}
+	parts := make([]string, 0, len(coords)+1)
+	for _, c := range coords {
+		parts = append(parts, strconv.FormatFloat(c, 'f', -1, 64))
+	}
+	parts = append(parts, strconv.Itoa(b.Zoom))
+	return strings.Join(parts, ",")
+}
+
+// Item is an element of Response.List, with the current weather in a city.
+type Item struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	Main   openweathermap.Main `json:"main"`
+	Dt     int64               `json:"dt"`
+	Wind   openweathermap.Wind `json:"wind"`
+	Clouds struct {
+		All int `json:"all"`
+	} `json:"clouds"`
+	// Visibility is in meters, up to 10 km.
+	Visibility int `json:"visibility"`
+	// Rain and Snow are the precipitation volumes in mm for the last one
+	// and three hours, if any.
+	Rain struct {
+		OneHour    *float64 `json:"1h"`
+		ThreeHours *float64 `json:"3h"`
+	} `json:"rain"`
+	Snow struct {
+		OneHour    *float64 `json:"1h"`
+		ThreeHours *float64 `json:"3h"`
+	} `json:"snow"`
+	Sys struct {
+		Country string `json:"country"`
+		// Timezone is the shift in seconds from UTC. It is only reported by
+		// the group request, and is nil otherwise.
+		Timezone *int  `json:"timezone"`
+		Sunrise  int64 `json:"sunrise"`
+		Sunset   int64 `json:"sunset"`
+	} `json:"sys"`
+	Weather []struct {
+		ID          openweathermap.ConditionCode `json:"id"`
+		Main        string                       `json:"main"`
+		Description string                       `json:"description"`
+		Icon        string                       `json:"icon"`
+	} `json:"weather"`
+}
+
+// Location returns the time zone of the city, or nil if the response does
+// not report it. The API only reports its offset from UTC, so the returned
+// zone does not follow daylight saving time changes.
+func (i *Item) Location() *time.Location {
+	if i.Sys.Timezone == nil {
+		return nil
+	}
+	return time.FixedZone("", *i.Sys.Timezone)
+}
+
+// Time returns the time of the data calculation in the given location, or
+// the zero time if it is not reported. If loc is nil, UTC is used.
+func (i *Item) Time(loc *time.Location) time.Time {
+	return apiutil.UnixTime(i.Dt, loc)
+}
+
+// SunriseTime returns the sunrise time in the given location, or the zero
+// time if it is not reported. If loc is nil, UTC is used.
+func (i *Item) SunriseTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(i.Sys.Sunrise, loc)
+}
+
+// SunsetTime returns the sunset time in the given location, or the zero time
+// if it is not reported. If loc is nil, UTC is used.
+func (i *Item) SunsetTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(i.Sys.Sunset, loc)
+}
+
+// ConvertUnits converts the temperatures and the wind speeds of all the
+// cities in place from one unit system to another.
+func (r *Response) ConvertUnits(from, to openweathermap.Units) {
+	convertUnits(r.List, from, to)
+}
+
+// ConvertUnits is like Response.ConvertUnits.
+func (r *GroupResponse) ConvertUnits(from, to openweathermap.Units) {
+	convertUnits(r.List, from, to)
+}
+
+func convertUnits(items []Item, from, to openweathermap.Units) {
+	for idx := range items {
+		items[idx].Main.ConvertUnits(from, to)
+		items[idx].Wind.ConvertUnits(from, to)
+	}
+}
+
+// Client is a data find API client.
+type Client struct {
+	owm *openweathermap.Client
+}
+
+// NewClient returns a data find API client that executes its requests with
+// the given OpenWeatherMap client.
+func NewClient(c *openweathermap.Client) *Client {
+	return &Client{owm: c}
 }
 
 // Request executes a data find request.
+// If debug is true, requests are logged to stderr.
+//
+// Deprecated: use Client.Request instead.
 func Request(appID, query string, units openweathermap.Units, debug bool) (*Response, error) {
-	u := baseURL // copy
-	u.Path += "find"
-	q := u.Query()
-	q.Set("appid", appID)
-	q.Set("q", query)
-	q.Set("units", string(units))
-	u.RawQuery = q.Encode()
+	owm := openweathermap.NewClient(appID)
 	if debug {
-		fmt.Fprintf(os.Stderr, "URL: %s", u.String())
+		owm.Logger = openweathermap.DebugLogger()
 	}
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return nil, fmt.Errorf("HTTP GET failed: %w", err)
+	return NewClient(owm).Request(query, units)
+}
+
+// Request executes a data find request. If units is empty, the client's
+// default units are used.
+func (c *Client) Request(query string, units openweathermap.Units) (*Response, error) {
+	return c.RequestContext(context.Background(), query, units)
+}
+
+// RequestContext is like Request, but the request is bound to the given
+// context.
+func (c *Client) RequestContext(ctx context.Context, query string, units openweathermap.Units) (*Response, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	var apiResp Response
+	if err := c.request(ctx, "/find", q, units, "", &apiResp); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return &apiResp, nil
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read HTTP body: %w", err)
+// Circle returns the current weather in the cnt cities closest to the given
+// coordinates, or in 10 cities if cnt is zero. The API returns up to 50
+// cities. If units or lang are empty, the client's defaults are used.
+func (c *Client) Circle(lat, lon float64, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	return c.CircleContext(context.Background(), lat, lon, cnt, units, lang)
+}
+
+// CircleContext is like Circle, but the request is bound to the given
+// context.
+func (c *Client) CircleContext(ctx context.Context, lat, lon float64, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	q := url.Values{}
+	openweathermap.ByCoordinates(lat, lon).Set(q)
+	if cnt != 0 {
+		q.Set("cnt", strconv.Itoa(cnt))
 	}
-	if debug {
-		fmt.Fprintf(os.Stderr, "Response: %s", string(body))
+	var apiResp Response
+	if err := c.request(ctx, "/find", q, units, lang, &apiResp); err != nil {
+		return nil, err
 	}
+	return &apiResp, nil
+}
 
-	var apiResp Response
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+// Group returns the current weather in the cities with the given IDs, at
+// least one and up to MaxGroupIDs. If units or lang are empty, the client's
+// defaults are used.
+func (c *Client) Group(ids []int, units openweathermap.Units, lang openweathermap.Lang) (*GroupResponse, error) {
+	return c.GroupContext(context.Background(), ids, units, lang)
+}
+
+// GroupContext is like Group, but the request is bound to the given context.
+func (c *Client) GroupContext(ctx context.Context, ids []int, units openweathermap.Units, lang openweathermap.Lang) (*GroupResponse, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no city IDs")
 	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Request failed with %s: %s", apiResp.Cod, apiResp.Message)
+	if len(ids) > MaxGroupIDs {
+		return nil, fmt.Errorf("too many city IDs: got %d, the maximum is %d", len(ids), MaxGroupIDs)
 	}
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, strconv.Itoa(id))
+	}
+	q := url.Values{}
+	q.Set("id", strings.Join(idStrings, ","))
+	var apiResp GroupResponse
+	if err := c.request(ctx, "/group", q, units, lang, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
 
-	if debug {
-		fmt.Fprintf(os.Stderr, "%s\n", string(body))
+// Box returns the current weather in the cities within the given bounding
+// box. If units or lang are empty, the client's defaults are used.
+func (c *Client) Box(box BoundingBox, units openweathermap.Units, lang openweathermap.Lang) (*GroupResponse, error) {
+	return c.BoxContext(context.Background(), box, units, lang)
+}
+
+// BoxContext is like Box, but the request is bound to the given context.
+func (c *Client) BoxContext(ctx context.Context, box BoundingBox, units openweathermap.Units, lang openweathermap.Lang) (*GroupResponse, error) {
+	q := url.Values{}
+	q.Set("bbox", box.String())
+	var apiResp GroupResponse
+	if err := c.request(ctx, "/box/city", q, units, lang, &apiResp); err != nil {
+		return nil, err
 	}
 	return &apiResp, nil
 }
+
+// request executes a request with the given query parameters and decodes its
+// response into v.
+func (c *Client) request(ctx context.Context, path string, params url.Values, units openweathermap.Units, lang openweathermap.Lang, v any) error {
+	u, err := c.owm.URL(openweathermap.DataAPI, path)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	for key, values := range params {
+		q[key] = values
+	}
+	apiutil.SetUnitsLang(q, units, lang, c.owm.Units, c.owm.Lang)
+	u.RawQuery = q.Encode()
+
+	body, err := c.owm.GetContext(ctx, u)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+// Package forecast implements OpenWeatherMap's 5 day / 3 hour forecast API
+// described at https://openweathermap.org/forecast5 . Unlike the One Call
+// API 3.0, it is available with the free subscription.
+//
+// It also implements the hourly forecast for 4 days and the daily forecast
+// for 16 days, described at https://openweathermap.org/api/hourly-forecast
+// and https://openweathermap.org/forecast16 , and the climatic forecast for
+// 30 days, described at https://openweathermap.org/api/forecast30 , that
+// require a paid subscription.
+package forecast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/insomniacslk/openweathermap"
+	"github.com/insomniacslk/openweathermap/internal/apiutil"
+)
+
+// Response represents a 5 day / 3 hour or an hourly forecast response.
+type Response struct {
+	Cod     string  `json:"cod"`
+	Message float64 `json:"message"`
+	// Cnt is the number of items in List.
+	Cnt  int    `json:"cnt"`
+	List []Item `json:"list"`
+	City City   `json:"city"`
+}
+
+// Item is an element of Response.List, with the forecast for a step of three
+// hours, or of one hour for the hourly forecast.
+type Item struct {
+	Dt      int64               `json:"dt"`
+	Main    openweathermap.Main `json:"main"`
+	Weather []struct {
+		ID          openweathermap.ConditionCode `json:"id"`
+		Main        string                       `json:"main"`
+		Description string                       `json:"description"`
+		Icon        string                       `json:"icon"`
+	} `json:"weather"`
+	Clouds struct {
+		All int `json:"all"`
+	} `json:"clouds"`
+	Wind openweathermap.Wind `json:"wind"`
+	// Visibility is in meters, up to 10 km.
+	Visibility int `json:"visibility"`
+	// Pop is the probability of precipitation, from 0 to 1.
+	Pop float64 `json:"pop"`
+	// Rain and Snow are the precipitation volumes in mm for the step, if
+	// any. ThreeHours is set by the 5 day / 3 hour forecast and OneHour by
+	// the hourly forecast.
+	Rain struct {
+		OneHour    *float64 `json:"1h"`
+		ThreeHours *float64 `json:"3h"`
+	} `json:"rain"`
+	Snow struct {
+		OneHour    *float64 `json:"1h"`
+		ThreeHours *float64 `json:"3h"`
+	} `json:"snow"`
+	Sys struct {
+		// Pod is the part of the day, "d" for day or "n" for night.
+		Pod string `json:"pod"`
+	} `json:"sys"`
+	// DtTxt is the time of the forecast in UTC, formatted as
+	// "2006-01-02 15:04:05".
+	DtTxt string `json:"dt_txt"`
+}
+
+// City is the location of a forecast.
+type City struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	Country    string `json:"country"`
+	Population int    `json:"population"`
+	// Timezone is the shift in seconds from UTC.
+	Timezone int   `json:"timezone"`
+	Sunrise  int64 `json:"sunrise"`
+	Sunset   int64 `json:"sunset"`
+}
+
+// Location returns the time zone of the city. The API only reports its
+// offset from UTC, so the returned zone does not follow daylight saving time
+// changes.
+func (c *City) Location() *time.Location {
+	return time.FixedZone("", c.Timezone)
+}
+
+// Time returns the time of the forecast in the given location, usually
+// obtained with City.Location, or the zero time if it is not reported. If loc
+// is nil, UTC is used.
+func (i *Item) Time(loc *time.Location) time.Time {
+	return apiutil.UnixTime(i.Dt, loc)
+}
+
+// ConvertUnits converts the temperatures and the wind speeds of all the
+// steps in place from one unit system to another.
+func (r *Response) ConvertUnits(from, to openweathermap.Units) {
+	for idx := range r.List {
+		r.List[idx].Main.ConvertUnits(from, to)
+		r.List[idx].Wind.ConvertUnits(from, to)
+	}
+}
+
+// Weather converts the response into the shape of a One Call API response,
+// with the forecast steps as hourly data, so that it can be handled by the
+// same code. Values that this API does not report, like the dew point and
+// the UV index, are left to zero. The One Call API reports the precipitation
+// per hour, so the volume of each three hours step is spread evenly over its
+// hours.
+func (r *Response) Weather() *openweathermap.Weather {
+	w := openweathermap.Weather{
+		Lat:            r.City.Coord.Lat,
+		Lon:            r.City.Coord.Lon,
+		TimezoneOffset: r.City.Timezone,
+		Hourly:         make([]openweathermap.PointWeatherSummary, 0, len(r.List)),
+	}
+	for _, item := range r.List {
+		var s openweathermap.PointWeatherSummary
+		s.Dt = item.Dt
+		s.Pressure = int(math.Round(item.Main.Pressure))
+		s.Humidity = item.Main.Humidity
+		s.Clouds = item.Clouds.All
+		s.Visibility = item.Visibility
+		s.WindSpeed = item.Wind.Speed
+		s.WindDeg = int(math.Round(item.Wind.Deg))
+		s.WindGust = item.Wind.Gust
+		s.Pop = item.Pop
+		s.Weather = item.Weather
+		s.Temp = item.Main.Temp
+		s.FeelsLike = item.Main.FeelsLike
+		s.Rain.OneHour = perHour(item.Rain.OneHour, item.Rain.ThreeHours)
+		s.Snow.OneHour = perHour(item.Snow.OneHour, item.Snow.ThreeHours)
+		w.Hourly = append(w.Hourly, s)
+	}
+	return &w
+}
+
+// perHour returns the hourly precipitation volume of a step, given its one
+// hour or three hours volume.
+func perHour(oneHour, threeHours *float64) *float64 {
+	if oneHour != nil || threeHours == nil {
+		return oneHour
+	}
+	v := *threeHours / 3
+	return &v
+}
+
+// DailyResponse represents a daily or a climatic forecast response.
+type DailyResponse struct {
+	Cod     string  `json:"cod"`
+	Message float64 `json:"message"`
+	// Cnt is the number of items in List.
+	Cnt  int         `json:"cnt"`
+	List []DailyItem `json:"list"`
+	City City        `json:"city"`
+}
+
+// DailyItem is an element of DailyResponse.List, with the forecast for a
+// day.
+type DailyItem struct {
+	Dt      int64 `json:"dt"`
+	Sunrise int64 `json:"sunrise"`
+	Sunset  int64 `json:"sunset"`
+	Temp    struct {
+		Day   float64 `json:"day"`
+		Min   float64 `json:"min"`
+		Max   float64 `json:"max"`
+		Night float64 `json:"night"`
+		Eve   float64 `json:"eve"`
+		Morn  float64 `json:"morn"`
+	} `json:"temp"`
+	FeelsLike struct {
+		Day   float64 `json:"day"`
+		Night float64 `json:"night"`
+		Eve   float64 `json:"eve"`
+		Morn  float64 `json:"morn"`
+	} `json:"feels_like"`
+	Pressure int `json:"pressure"`
+	Humidity int `json:"humidity"`
+	Weather  []struct {
+		ID          openweathermap.ConditionCode `json:"id"`
+		Main        string                       `json:"main"`
+		Description string                       `json:"description"`
+		Icon        string                       `json:"icon"`
+	} `json:"weather"`
+	Speed  float64  `json:"speed"`
+	Deg    int      `json:"deg"`
+	Gust   *float64 `json:"gust"`
+	Clouds int      `json:"clouds"`
+	// Pop is the probability of precipitation, from 0 to 1. It is not
+	// reported by the climatic forecast.
+	Pop float64 `json:"pop"`
+	// Rain and Snow are the precipitation volumes in mm for the day, if
+	// any.
+	Rain *float64 `json:"rain"`
+	Snow *float64 `json:"snow"`
+}
+
+// Time returns the time of the forecast in the given location, usually
+// obtained with City.Location, or the zero time if it is not reported. If loc
+// is nil, UTC is used.
+func (i *DailyItem) Time(loc *time.Location) time.Time {
+	return apiutil.UnixTime(i.Dt, loc)
+}
+
+// ConvertUnits converts the temperatures and the wind speeds of all the days
+// in place from one unit system to another.
+func (r *DailyResponse) ConvertUnits(from, to openweathermap.Units) {
+	for idx := range r.List {
+		item := &r.List[idx]
+		for _, t := range []*float64{
+			&item.Temp.Day, &item.Temp.Min, &item.Temp.Max, &item.Temp.Night, &item.Temp.Eve, &item.Temp.Morn,
+			&item.FeelsLike.Day, &item.FeelsLike.Night, &item.FeelsLike.Eve, &item.FeelsLike.Morn,
+		} {
+			*t = openweathermap.ConvertTemp(*t, from, to)
+		}
+		wind := openweathermap.Wind{Speed: item.Speed, Gust: item.Gust}
+		wind.ConvertUnits(from, to)
+		item.Speed, item.Gust = wind.Speed, wind.Gust
+	}
+}
+
+// DailyWeatherSummary converts the item into the shape of a daily summary of
+// the One Call API. Values that this API does not report, like the dew point
+// and the moon phase, are left to zero.
+func (i *DailyItem) DailyWeatherSummary() openweathermap.DailyWeatherSummary {
+	var s openweathermap.DailyWeatherSummary
+	s.Dt = i.Dt
+	s.Sunrise = int(i.Sunrise)
+	s.Sunset = int(i.Sunset)
+	s.Pressure = i.Pressure
+	s.Humidity = i.Humidity
+	s.Clouds = i.Clouds
+	s.WindSpeed = i.Speed
+	s.WindDeg = i.Deg
+	s.WindGust = i.Gust
+	s.Pop = i.Pop
+	s.Weather = i.Weather
+	s.Temp = i.Temp
+	s.FeelsLike = i.FeelsLike
+	s.Rain = i.Rain
+	s.Snow = i.Snow
+	return s
+}
+
+// Weather converts the response into the shape of a One Call API response,
+// with the forecast days as daily data, so that it can be handled by the
+// same code. See DailyItem.DailyWeatherSummary.
+func (r *DailyResponse) Weather() *openweathermap.Weather {
+	w := openweathermap.Weather{
+		Lat:            r.City.Coord.Lat,
+		Lon:            r.City.Coord.Lon,
+		TimezoneOffset: r.City.Timezone,
+		Daily:          make([]openweathermap.DailyWeatherSummary, 0, len(r.List)),
+	}
+	for idx := range r.List {
+		w.Daily = append(w.Daily, r.List[idx].DailyWeatherSummary())
+	}
+	return &w
+}
+
+// Client is a forecast API client.
+type Client struct {
+	owm *openweathermap.Client
+}
+
+// NewClient returns a forecast API client that executes its requests with
+// the given OpenWeatherMap client.
+func NewClient(c *openweathermap.Client) *Client {
+	return &Client{owm: c}
+}
+
+// Request executes a 5 day / 3 hour forecast request for the location
+// selected by query, e.g. openweathermap.ByCityID(2643743). If cnt is not
+// zero, only the first cnt steps are returned. If units or lang are empty,
+// the client's defaults are used.
+func (c *Client) Request(query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	return c.RequestContext(context.Background(), query, cnt, units, lang)
+}
+
+// RequestContext is like Request, but the request is bound to the given
+// context.
+func (c *Client) RequestContext(ctx context.Context, query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	var apiResp Response
+	if err := c.request(ctx, openweathermap.DataAPI, "/forecast", query, cnt, units, lang, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
+
+// Hourly executes an hourly forecast request for the location selected by
+// query. It returns up to 96 steps, or the first cnt if cnt is not zero. It
+// is served by the ProAPI family. If units or lang are empty, the client's
+// defaults are used.
+func (c *Client) Hourly(query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	return c.HourlyContext(context.Background(), query, cnt, units, lang)
+}
+
+// HourlyContext is like Hourly, but the request is bound to the given
+// context.
+func (c *Client) HourlyContext(ctx context.Context, query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*Response, error) {
+	var apiResp Response
+	if err := c.request(ctx, openweathermap.ProAPI, "/forecast/hourly", query, cnt, units, lang, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
+
+// Daily executes a daily forecast request for the location selected by
+// query. It returns cnt days, from 1 to 16, or 7 days if cnt is zero. If
+// units or lang are empty, the client's defaults are used.
+func (c *Client) Daily(query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*DailyResponse, error) {
+	return c.DailyContext(context.Background(), query, cnt, units, lang)
+}
+
+// DailyContext is like Daily, but the request is bound to the given context.
+func (c *Client) DailyContext(ctx context.Context, query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*DailyResponse, error) {
+	var apiResp DailyResponse
+	if err := c.request(ctx, openweathermap.DataAPI, "/forecast/daily", query, cnt, units, lang, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
+
+// Climate executes a climatic forecast request for the location selected by
+// query. It returns cnt days, from 1 to 30, or 30 days if cnt is zero. It is
+// served by the ProAPI family. If units or lang are empty, the client's
+// defaults are used.
+func (c *Client) Climate(query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*DailyResponse, error) {
+	return c.ClimateContext(context.Background(), query, cnt, units, lang)
+}
+
+// ClimateContext is like Climate, but the request is bound to the given
+// context.
+func (c *Client) ClimateContext(ctx context.Context, query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang) (*DailyResponse, error) {
+	var apiResp DailyResponse
+	if err := c.request(ctx, openweathermap.ProAPI, "/forecast/climate", query, cnt, units, lang, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
+
+// request executes a forecast request and decodes its response into v.
+func (c *Client) request(ctx context.Context, api openweathermap.API, path string, query openweathermap.Query, cnt int, units openweathermap.Units, lang openweathermap.Lang, v any) error {
+	u, err := c.owm.URL(api, path)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	query.Set(q)
+	if cnt != 0 {
+		q.Set("cnt", strconv.Itoa(cnt))
+	}
+	apiutil.SetUnitsLang(q, units, lang, c.owm.Units, c.owm.Lang)
+	u.RawQuery = q.Encode()
+
+	body, err := c.owm.GetContext(ctx, u)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	return nil
+}
@@ -5,26 +5,22 @@ package geocoding
 // TODO add search by zip code
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"os"
 	"strconv"
-)
 
-var baseURL = url.URL{
-	Scheme: "https",
-	Host:   "api.openweathermap.org",
-	Path:   "/geo/1.0/",
-}
+	"github.com/insomniacslk/openweathermap"
+)
 
 // DefaultLimit is the default maximum number of geocoding results to be
 // returned.
 const DefaultLimit = 5
 
 // FailureResponse is the response structure used when an API call has failed.
+//
+// Deprecated: failed requests are reported as *openweathermap.APIError.
 type FailureResponse struct {
 	Cod     int    `json:"cod"`
 	Message string `json:"message"`
@@ -55,78 +51,89 @@ type ReverseGeocodingRequest struct {
 	Limit int
 }
 
+// Client is a geocoding API client.
+type Client struct {
+	owm *openweathermap.Client
+}
+
+// NewClient returns a geocoding API client that executes its requests with
+// the given OpenWeatherMap client.
+func NewClient(c *openweathermap.Client) *Client {
+	return &Client{owm: c}
+}
+
 // DirectGeocoding executes a direct geocoding request.
+// If debug is true, requests are logged to stderr.
+//
+// Deprecated: use Client.DirectGeocoding instead.
 func DirectGeocoding(appID string, req *DirectGeocodingRequest, debug bool) (*Response, error) {
-	u := baseURL // copy
-	u.Path += "direct"
-	q := u.Query()
-	q.Set("q", fmt.Sprintf("%s,%s,%s", req.City, req.State, req.CountryCode))
-	q.Set("limit", strconv.FormatInt(int64(req.Limit), 10))
-	u.RawQuery = q.Encode()
+	owm := openweathermap.NewClient(appID)
+	if debug {
+		owm.Logger = openweathermap.DebugLogger()
+	}
+	return NewClient(owm).DirectGeocoding(req)
+}
+
+// DirectGeocoding executes a direct geocoding request.
+func (c *Client) DirectGeocoding(req *DirectGeocodingRequest) (*Response, error) {
+	return c.DirectGeocodingContext(context.Background(), req)
+}
 
-	body, err := request(appID, req.Limit, &u, debug)
+// DirectGeocodingContext is like DirectGeocoding, but the request is bound
+// to the given context.
+func (c *Client) DirectGeocodingContext(ctx context.Context, req *DirectGeocodingRequest) (*Response, error) {
+	u, err := c.owm.URL(openweathermap.GeocodingAPI, "/direct")
 	if err != nil {
 		return nil, err
 	}
+	q := u.Query()
+	q.Set("q", fmt.Sprintf("%s,%s,%s", req.City, req.State, req.CountryCode))
+	u.RawQuery = q.Encode()
 
-	var apiResp Response
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
-	}
+	return c.request(ctx, req.Limit, u)
+}
 
+// ReverseGeocoding executes a reverse geocoding request.
+// If debug is true, requests are logged to stderr.
+//
+// Deprecated: use Client.ReverseGeocoding instead.
+func ReverseGeocoding(appID string, req *ReverseGeocodingRequest, debug bool) (*Response, error) {
+	owm := openweathermap.NewClient(appID)
 	if debug {
-		fmt.Fprintf(os.Stderr, "%s\n", string(body))
+		owm.Logger = openweathermap.DebugLogger()
 	}
-	return &apiResp, nil
+	return NewClient(owm).ReverseGeocoding(req)
 }
 
-func request(appID string, limit int, u *url.URL, debug bool) ([]byte, error) {
-	q := u.Query()
-	q.Set("appid", appID)
-	if limit == 0 {
-		limit = DefaultLimit
-	}
-	q.Set("limit", strconv.FormatInt(int64(limit), 10))
-	u.RawQuery = q.Encode()
-
-	if debug {
-		fmt.Fprintf(os.Stderr, "URL: %s", u.String())
-	}
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return nil, fmt.Errorf("HTTP GET failed: %w", err)
-	}
-	defer resp.Body.Close()
+// ReverseGeocoding executes a reverse geocoding request.
+func (c *Client) ReverseGeocoding(req *ReverseGeocodingRequest) (*Response, error) {
+	return c.ReverseGeocodingContext(context.Background(), req)
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+// ReverseGeocodingContext is like ReverseGeocoding, but the request is bound
+// to the given context.
+func (c *Client) ReverseGeocodingContext(ctx context.Context, req *ReverseGeocodingRequest) (*Response, error) {
+	u, err := c.owm.URL(openweathermap.GeocodingAPI, "/reverse")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read HTTP body: %w", err)
-	}
-	if debug {
-		fmt.Fprintf(os.Stderr, "Response: %s", string(body))
-	}
-	// first check if the call has failed
-	if resp.StatusCode != 200 {
-		var fresp FailureResponse
-		if err := json.Unmarshal(body, &fresp); err != nil {
-			return nil, fmt.Errorf("HTTP GET returned status '%s', and could not unmarshal response message: %w", resp.Status, err)
-		}
-		return nil, fmt.Errorf("Request failed with %d: %s", fresp.Cod, fresp.Message)
+		return nil, err
 	}
+	q := u.Query()
+	q.Set("lat", strconv.FormatFloat(req.Lat, 'g', -1, 32))
+	q.Set("lon", strconv.FormatFloat(req.Lon, 'g', -1, 32))
+	u.RawQuery = q.Encode()
 
-	return body, nil
+	return c.request(ctx, req.Limit, u)
 }
 
-// ReverseGeocoding executes a reverse geocoding request.
-func ReverseGeocoding(appID string, req *ReverseGeocodingRequest, debug bool) (*Response, error) {
-	u := baseURL // copy
-	u.Path += "reverse"
+func (c *Client) request(ctx context.Context, limit int, u *url.URL) (*Response, error) {
 	q := u.Query()
-	q.Set("lat", strconv.FormatFloat(req.Lat, 'g', -1, 32))
-	q.Set("lon", strconv.FormatFloat(req.Lon, 'g', -1, 32))
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	q.Set("limit", strconv.FormatInt(int64(limit), 10))
 	u.RawQuery = q.Encode()
 
-	body, err := request(appID, req.Limit, &u, debug)
+	body, err := c.owm.GetContext(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -134,9 +141,5 @@ func ReverseGeocoding(appID string, req *ReverseGeocodingRequest, debug bool) (*
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
 	}
-
-	if debug {
-		fmt.Fprintf(os.Stderr, "%s\n", string(body))
-	}
 	return &apiResp, nil
 }
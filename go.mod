@@ -1,6 +1,6 @@
 module github.com/insomniacslk/openweathermap
 
-go 1.18
+go 1.21
 
 require github.com/spf13/pflag v1.0.5
 
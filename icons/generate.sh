@@ -7,7 +7,7 @@ cd "${script_dir}"
 echo -e "package icons\n// WARNING: generated file, do not modify\n\n// Icons contains all the openweathermap icons\nvar Icons = map[string][]byte{" > icons.go
 
 # from https://openweathermap.org/weather-conditions#Weather-Condition-Codes-2
-codes="01d 01n 02d 02n 03d 03n 04d 04n 09d 10d 11d 13d 50d"
+codes="01d 01n 02d 02n 03d 03n 04d 04n 09d 10d 10n 11d 13d 50d"
 
 rm -f *.png
 for code in ${codes}
@@ -0,0 +1,28 @@
+package icons
+
+import "strings"
+
+// sameAtNight lists the conditions whose night icon is the same image as the
+// day one, so only the day variant is stored in Icons.
+var sameAtNight = map[string]bool{
+	"09": true, // shower rain
+	"11": true, // thunderstorm
+	"13": true, // snow
+	"50": true, // mist
+}
+
+// Lookup returns the icon with the given code, e.g. "09n". OpenWeatherMap
+// uses the same image for the day and night variants of shower rain,
+// thunderstorm, snow and mist, so for those Lookup falls back to the day
+// variant when the night one is requested. Other night icons, e.g. "10n" for
+// rain, are different images, and are never replaced by the day ones.
+func Lookup(code string) ([]byte, bool) {
+	if icon, ok := Icons[code]; ok {
+		return icon, true
+	}
+	if cond, ok := strings.CutSuffix(code, "n"); ok && sameAtNight[cond] {
+		icon, ok := Icons[cond+"d"]
+		return icon, ok
+	}
+	return nil, false
+}
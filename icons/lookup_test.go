@@ -0,0 +1,39 @@
+package icons
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"01d", "01d"},
+		{"01n", "01n"},
+		{"09n", "09d"},
+		{"11n", "11d"},
+		{"13n", "13d"},
+		{"50n", "50d"},
+	} {
+		icon, ok := Lookup(tc.code)
+		if !ok {
+			t.Errorf("%s: not found", tc.code)
+			continue
+		}
+		if !bytes.Equal(icon, Icons[tc.want]) {
+			t.Errorf("%s: did not get the %s image", tc.code, tc.want)
+		}
+	}
+	for _, code := range []string{"", "99d", "99n", "01x"} {
+		if _, ok := Lookup(code); ok {
+			t.Errorf("%q: unexpectedly found", code)
+		}
+	}
+	// rain at night has its own image, and must not be replaced by the day
+	// one.
+	if icon, ok := Lookup("10n"); ok && bytes.Equal(icon, Icons["10d"]) {
+		t.Error("10n: got the 10d image")
+	}
+}
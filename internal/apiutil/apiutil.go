@@ -0,0 +1,38 @@
+// Package apiutil contains the helpers shared by the openweathermap package
+// and by the API packages, that are not part of the public API.
+package apiutil
+
+import (
+	"net/url"
+	"time"
+)
+
+// SetUnitsLang sets units and language in a query, falling back to the
+// given defaults, usually the client's ones, if they are empty.
+func SetUnitsLang[U, L ~string](q url.Values, units U, lang L, defaultUnits U, defaultLang L) {
+	if units == "" {
+		units = defaultUnits
+	}
+	if units != "" {
+		q.Set("units", string(units))
+	}
+	if lang == "" {
+		lang = defaultLang
+	}
+	if lang != "" {
+		q.Set("lang", string(lang))
+	}
+}
+
+// UnixTime converts a Unix timestamp from a response into a time in the given
+// location, or in UTC if loc is nil. Zero timestamps, used by the API for
+// missing values, are converted to the zero time.
+func UnixTime(unix int64, loc *time.Location) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Unix(unix, 0).In(loc)
+}
@@ -0,0 +1,39 @@
+package apiutil
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSetUnitsLang(t *testing.T) {
+	for _, tc := range []struct {
+		units, lang, defaultUnits, defaultLang string
+		want                                   string
+	}{
+		{"", "", "", "", ""},
+		{"metric", "it", "", "", "lang=it&units=metric"},
+		{"", "", "imperial", "de", "lang=de&units=imperial"},
+		{"metric", "", "imperial", "de", "lang=de&units=metric"},
+		{"", "it", "imperial", "de", "lang=it&units=imperial"},
+	} {
+		q := url.Values{}
+		SetUnitsLang(q, tc.units, tc.lang, tc.defaultUnits, tc.defaultLang)
+		if got := q.Encode(); got != tc.want {
+			t.Errorf("%+v: got %q, want %q", tc, got, tc.want)
+		}
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	if got := UnixTime(0, time.UTC); !got.IsZero() {
+		t.Errorf("UnixTime(0) = %v, want the zero time", got)
+	}
+	if got := UnixTime(1700000000, nil); got.Location() != time.UTC || got.Unix() != 1700000000 {
+		t.Errorf("UnixTime(1700000000, nil) = %v, want it in UTC", got)
+	}
+	loc := time.FixedZone("", 3600)
+	if got := UnixTime(1700000000, loc); got.Location() != loc || got.Unix() != 1700000000 {
+		t.Errorf("UnixTime(1700000000, loc) = %v, want it in loc", got)
+	}
+}
@@ -0,0 +1,223 @@
+// Package lunar calculates the phase of the moon and the times of moonrise
+// and moonset for a given location, without calling any API. The moon's
+// position is computed with the main periodic terms of the ELP-2000/82
+// theory from Jean Meeus' "Astronomical Algorithms", chapter 47, which is
+// accurate to a few minutes of rise and set time.
+package lunar
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Phase is the phase of the moon, using the same convention as the
+// moon_phase field of OpenWeatherMap's One Call API: 0 and 1 are the new
+// moon, 0.25 is the first quarter, 0.5 is the full moon and 0.75 is the
+// last quarter, with the values in between being the waxing and waning
+// crescents and gibbous moons. Hence the API value can be converted
+// directly, e.g. lunar.Phase(daily.MoonPhase).Name().
+type Phase float64
+
+// SynodicMonth is the mean time between two new moons.
+const SynodicMonth = time.Duration(29.530588853 * 24 * 60 * 60 * 1e9)
+
+// names of the phases, see Phase.Name.
+const (
+	NewMoon        = "new moon"
+	WaxingCrescent = "waxing crescent"
+	FirstQuarter   = "first quarter"
+	WaxingGibbous  = "waxing gibbous"
+	FullMoon       = "full moon"
+	WaningGibbous  = "waning gibbous"
+	LastQuarter    = "last quarter"
+	WaningCrescent = "waning crescent"
+)
+
+// PhaseAt returns the phase of the moon at the given time.
+func PhaseAt(t time.Time) Phase {
+	jc := julianCentury(t)
+	moonLon, _, _ := moonPosition(jc)
+	elongation := normalize(moonLon - sunLongitude(jc))
+	return Phase(elongation / 360)
+}
+
+// Illumination returns the illuminated fraction of the moon's disk, from 0
+// at new moon to 1 at full moon.
+func (p Phase) Illumination() float64 {
+	return (1 - math.Cos(2*math.Pi*float64(p))) / 2
+}
+
+// Name returns the English name of the phase. The new moon, the quarters
+// and the full moon are instants, so they are reported within half a day of
+// their exact time.
+func (p Phase) Name() string {
+	tolerance := 12 / SynodicMonth.Hours()
+	v := math.Mod(float64(p), 1)
+	if v < 0 {
+		v++
+	}
+	switch {
+	case v < tolerance || v > 1-tolerance:
+		return NewMoon
+	case v < 0.25-tolerance:
+		return WaxingCrescent
+	case v <= 0.25+tolerance:
+		return FirstQuarter
+	case v < 0.5-tolerance:
+		return WaxingGibbous
+	case v <= 0.5+tolerance:
+		return FullMoon
+	case v < 0.75-tolerance:
+		return WaningGibbous
+	case v <= 0.75+tolerance:
+		return LastQuarter
+	default:
+		return WaningCrescent
+	}
+}
+
+func (p Phase) String() string {
+	return fmt.Sprintf("%s (%.0f%%)", p.Name(), p.Illumination()*100)
+}
+
+// radians and degrees conversion helpers.
+func rad(deg float64) float64 { return deg * math.Pi / 180 }
+func deg(rad float64) float64 { return rad * 180 / math.Pi }
+
+// normalize returns an angle in degrees in the [0, 360) range.
+func normalize(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+// julianDay returns the Julian day of the given time.
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+}
+
+// julianCentury returns the number of Julian centuries since J2000.0.
+func julianCentury(t time.Time) float64 {
+	return (julianDay(t) - 2451545) / 36525
+}
+
+// sunLongitude returns the apparent ecliptic longitude of the sun in
+// degrees.
+func sunLongitude(jc float64) float64 {
+	meanLong := 280.46646 + jc*(36000.76983+jc*0.0003032)
+	meanAnom := rad(357.52911 + jc*(35999.05029-0.0001537*jc))
+	eqCenter := math.Sin(meanAnom)*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(2*meanAnom)*(0.019993-0.000101*jc) +
+		math.Sin(3*meanAnom)*0.000289
+	return normalize(meanLong + eqCenter)
+}
+
+// moonPosition returns the geocentric ecliptic longitude and latitude of
+// the moon in degrees, and its distance in kilometers.
+func moonPosition(jc float64) (lon, lat, dist float64) {
+	// mean longitude and elongation of the moon, mean anomalies of the sun
+	// and of the moon, and argument of latitude of the moon.
+	l := 218.3164477 + 481267.88123421*jc
+	d := rad(297.8501921 + 445267.1114034*jc)
+	m := rad(357.5291092 + 35999.0502909*jc)
+	mm := rad(134.9633964 + 477198.8675055*jc)
+	f := rad(93.2720950 + 483202.0175233*jc)
+
+	lon = l +
+		6.288774*math.Sin(mm) +
+		1.274027*math.Sin(2*d-mm) +
+		0.658314*math.Sin(2*d) +
+		0.213618*math.Sin(2*mm) -
+		0.185116*math.Sin(m) -
+		0.114332*math.Sin(2*f) +
+		0.058793*math.Sin(2*d-2*mm) +
+		0.057066*math.Sin(2*d-m-mm) +
+		0.053322*math.Sin(2*d+mm) +
+		0.045758*math.Sin(2*d-m) -
+		0.040923*math.Sin(m-mm) -
+		0.034720*math.Sin(d) -
+		0.030383*math.Sin(m+mm)
+	lat = 5.128122*math.Sin(f) +
+		0.280602*math.Sin(mm+f) +
+		0.277693*math.Sin(mm-f) +
+		0.173237*math.Sin(2*d-f) +
+		0.055413*math.Sin(2*d-mm+f) +
+		0.046271*math.Sin(2*d-mm-f)
+	dist = 385000.56 -
+		20905.355*math.Cos(mm) -
+		3699.111*math.Cos(2*d-mm) -
+		2955.968*math.Cos(2*d) -
+		569.925*math.Cos(2*mm)
+	return normalize(lon), lat, dist
+}
+
+// altitude returns the geocentric altitude of the moon above the horizon in
+// degrees at the given time and location, and its horizontal parallax in
+// degrees.
+func altitude(t time.Time, lat, lon float64) (alt, parallax float64) {
+	jc := julianCentury(t)
+	moonLon, moonLat, dist := moonPosition(jc)
+	obliq := rad(23.439291 - 0.0130042*jc)
+	lambda, beta := rad(moonLon), rad(moonLat)
+	ra := math.Atan2(math.Sin(lambda)*math.Cos(obliq)-math.Tan(beta)*math.Sin(obliq), math.Cos(lambda))
+	dec := math.Asin(math.Sin(beta)*math.Cos(obliq) + math.Cos(beta)*math.Sin(obliq)*math.Sin(lambda))
+	// Greenwich mean sidereal time in degrees.
+	gmst := 280.46061837 + 360.98564736629*(julianDay(t)-2451545) + 0.000387933*jc*jc
+	hourAngle := rad(normalize(gmst+lon) - deg(ra))
+	phi := rad(lat)
+	alt = deg(math.Asin(math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(hourAngle)))
+	parallax = deg(math.Asin(6378.14 / dist))
+	return alt, parallax
+}
+
+// horizonDistance returns how far the moon is above the altitude at which
+// it rises and sets, that accounts for parallax, refraction and the moon's
+// apparent radius.
+func horizonDistance(t time.Time, lat, lon float64) float64 {
+	alt, parallax := altitude(t, lat, lon)
+	return alt - (0.7275*parallax - 0.5667)
+}
+
+// RiseSet returns the times of moonrise and moonset on the given day at the
+// given location. The day is the calendar date of date in its location, and
+// the returned times are in that location too. Since the moon rises about
+// 50 minutes later every day, on some days it does not rise or does not set:
+// in that case the corresponding time is the zero time, like OpenWeatherMap
+// does.
+func RiseSet(date time.Time, lat, lon float64) (rise, set time.Time) {
+	loc := date.Location()
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+	const step = 10 * time.Minute
+	prevTime := start
+	prev := horizonDistance(prevTime, lat, lon)
+	for t := start.Add(step); !t.After(end) && (rise.IsZero() || set.IsZero()); t = t.Add(step) {
+		cur := horizonDistance(t, lat, lon)
+		if prev < 0 && cur >= 0 && rise.IsZero() {
+			rise = bisect(prevTime, t, lat, lon)
+		} else if prev >= 0 && cur < 0 && set.IsZero() {
+			set = bisect(prevTime, t, lat, lon)
+		}
+		prevTime, prev = t, cur
+	}
+	return rise, set
+}
+
+// bisect finds the time between a and b when the moon crosses the horizon,
+// to the second.
+func bisect(a, b time.Time, lat, lon float64) time.Time {
+	fa := horizonDistance(a, lat, lon)
+	for b.Sub(a) > time.Second {
+		mid := a.Add(b.Sub(a) / 2)
+		fm := horizonDistance(mid, lat, lon)
+		if (fa < 0) == (fm < 0) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+	}
+	return b.Truncate(time.Second)
+}
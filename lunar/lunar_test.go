@@ -0,0 +1,121 @@
+package lunar
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestPhaseAt checks the phase at the principal phases of January 2024, as
+// published by the US Naval Observatory.
+func TestPhaseAt(t *testing.T) {
+	for _, tc := range []struct {
+		t    string
+		want Phase
+		name string
+	}{
+		{"2024-01-04T03:30:00Z", 0.75, LastQuarter},
+		{"2024-01-11T11:57:00Z", 0, NewMoon},
+		{"2024-01-18T03:52:00Z", 0.25, FirstQuarter},
+		{"2024-01-25T17:54:00Z", 0.5, FullMoon},
+	} {
+		ts, err := time.Parse(time.RFC3339, tc.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := PhaseAt(ts)
+		// the new moon is either just before 1 or just after 0.
+		if diff := math.Abs(float64(got - tc.want)); diff > 0.001 && math.Abs(diff-1) > 0.001 {
+			t.Errorf("%s: got phase %.4f, want %.2f", tc.t, got, tc.want)
+		}
+		if name := got.Name(); name != tc.name {
+			t.Errorf("%s: got %q, want %q", tc.t, name, tc.name)
+		}
+	}
+}
+
+func TestPhaseName(t *testing.T) {
+	// the principal phases are reported within half a day of their time.
+	tolerance := 12 / SynodicMonth.Hours()
+	const eps = 1e-9
+	for _, tc := range []struct {
+		phase Phase
+		want  string
+	}{
+		{0, NewMoon},
+		{Phase(tolerance - eps), NewMoon},
+		{Phase(tolerance + eps), WaxingCrescent},
+		{Phase(0.25 - tolerance - eps), WaxingCrescent},
+		{Phase(0.25 - tolerance + eps), FirstQuarter},
+		{0.25, FirstQuarter},
+		{Phase(0.25 + tolerance - eps), FirstQuarter},
+		{Phase(0.25 + tolerance + eps), WaxingGibbous},
+		{Phase(0.5 - tolerance - eps), WaxingGibbous},
+		{0.5, FullMoon},
+		{Phase(0.5 + tolerance + eps), WaningGibbous},
+		{Phase(0.75 - tolerance - eps), WaningGibbous},
+		{0.75, LastQuarter},
+		{Phase(0.75 + tolerance + eps), WaningCrescent},
+		{Phase(1 - tolerance - eps), WaningCrescent},
+		{Phase(1 - tolerance + eps), NewMoon},
+		{1, NewMoon},
+		// values out of range wrap around.
+		{-0.5, FullMoon},
+		{1.25, FirstQuarter},
+	} {
+		if got := tc.phase.Name(); got != tc.want {
+			t.Errorf("%v: got %q, want %q", float64(tc.phase), got, tc.want)
+		}
+	}
+	if got, want := Phase(0.5).Illumination(), 1.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("full moon illumination: got %v, want %v", got, want)
+	}
+	if got, want := Phase(0.25).String(), "first quarter (50%)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRiseSet(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("tz database not available: %v", err)
+	}
+	const lat, lon = 51.5074, -0.1278
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, london) }
+	at := func(d, hour, min int) time.Time { return time.Date(2024, 1, d, hour, min, 0, 0, london) }
+	near := func(got, want time.Time) bool {
+		d := got.Sub(want)
+		return d > -3*time.Minute && d < 3*time.Minute
+	}
+
+	// on the day of the full moon it rises around sunset and sets around
+	// sunrise.
+	rise, set := RiseSet(day(25), lat, lon)
+	if !near(rise, at(25, 16, 0)) || !near(set, at(25, 8, 20)) {
+		t.Errorf("2024-01-25: got rise %s, set %s, want 16:00, 08:20", rise, set)
+	}
+	if rise.Location() != london || set.Location() != london {
+		t.Errorf("2024-01-25: got locations %s, %s, want %s", rise.Location(), set.Location(), london)
+	}
+
+	// near the last quarter it rises around midnight, so one day has no
+	// moonrise.
+	rise, set = RiseSet(day(2), lat, lon)
+	if !near(rise, at(2, 23, 2)) || set.IsZero() {
+		t.Errorf("2024-01-02: got rise %s, set %s, want 23:02 and a moonset", rise, set)
+	}
+	rise, set = RiseSet(day(3), lat, lon)
+	if !rise.IsZero() || set.IsZero() {
+		t.Errorf("2024-01-03: got rise %s, set %s, want no moonrise", rise, set)
+	}
+	rise, _ = RiseSet(day(4), lat, lon)
+	if !near(rise, at(4, 0, 12)) {
+		t.Errorf("2024-01-04: got rise %s, want 00:12", rise)
+	}
+
+	// and near the first quarter it sets around midnight.
+	rise, set = RiseSet(day(17), lat, lon)
+	if rise.IsZero() || !set.IsZero() {
+		t.Errorf("2024-01-17: got rise %s, set %s, want no moonset", rise, set)
+	}
+}
@@ -0,0 +1,55 @@
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Overview maps to a JSON response from the One Call API's overview
+// endpoint, that returns a human-readable summary of the weather.
+type Overview struct {
+	Lat             float64 `json:"lat"`
+	Lon             float64 `json:"lon"`
+	Tz              string  `json:"tz"`
+	Date            string  `json:"date"`
+	Units           Units   `json:"units"`
+	WeatherOverview string  `json:"weather_overview"`
+}
+
+// Overview returns a human-readable summary of the weather for today or
+// tomorrow, see https://openweathermap.org/api/one-call-3#weather_overview .
+// If date is the zero time, today's overview is returned. If units is empty,
+// the client's default is used. The summary is only available in English.
+func (c *Client) Overview(lat, lon float64, date time.Time, units Units) (*Overview, error) {
+	return c.OverviewContext(context.Background(), lat, lon, date, units)
+}
+
+// OverviewContext is like Overview, but the request is bound to the given
+// context.
+func (c *Client) OverviewContext(ctx context.Context, lat, lon float64, date time.Time, units Units) (*Overview, error) {
+	u, err := c.URL(OneCallAPI, "/overview")
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	setCoordinates(q, lat, lon)
+	if !date.IsZero() {
+		q.Set("date", date.Format("2006-01-02"))
+	}
+	if units := c.units(units); units != "" {
+		q.Set("units", string(units))
+	}
+	u.RawQuery = q.Encode()
+
+	body, err := c.GetContext(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	var apiResp Overview
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	return &apiResp, nil
+}
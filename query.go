@@ -0,0 +1,50 @@
+package openweathermap
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Query selects the location of a data API 2.5 request, e.g. of the current
+// weather or of the forecast. The API accepts either coordinates, a city
+// name, a city ID or a ZIP code, see https://openweathermap.org/current .
+// Use ByCoordinates, ByCityName, ByCityID or ByZipCode to build it.
+type Query struct {
+	params url.Values
+}
+
+// ByCoordinates returns a query by latitude and longitude.
+func ByCoordinates(lat, lon float64) Query {
+	q := url.Values{}
+	setCoordinates(q, lat, lon)
+	return Query{params: q}
+}
+
+// ByCityName returns a query by city name. The name can be followed by a
+// state code, only for the US, and by an ISO 3166 country code, separated by
+// commas, e.g. "London,uk" or "Portland,or,us".
+func ByCityName(name string) Query {
+	return Query{params: url.Values{"q": {name}}}
+}
+
+// ByCityID returns a query by OpenWeatherMap's city ID.
+func ByCityID(id int) Query {
+	return Query{params: url.Values{"id": {strconv.Itoa(id)}}}
+}
+
+// ByZipCode returns a query by ZIP code and ISO 3166 country code. If the
+// country is empty, the API searches in the USA.
+func ByZipCode(zip, country string) Query {
+	if country != "" {
+		zip += "," + country
+	}
+	return Query{params: url.Values{"zip": {zip}}}
+}
+
+// Set sets the query parameters that select the location in the given URL
+// query.
+func (q Query) Set(v url.Values) {
+	for key, values := range q.params {
+		v[key] = append([]string(nil), values...)
+	}
+}
@@ -0,0 +1,192 @@
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// QuotaError is returned when a call is refused by a non-blocking
+// RateLimiter. It matches ErrQuotaExceeded with errors.Is.
+type QuotaError struct {
+	// Window is the time window whose budget has been exhausted, either
+	// time.Minute or 24*time.Hour for the UTC day.
+	Window time.Duration
+	// Limit is the number of calls allowed in Window.
+	Limit int
+	// RetryAfter is the time to wait until a call is allowed again.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("quota of %d calls per %s exceeded, retry in %s", e.Limit, e.Window, e.RetryAfter)
+}
+
+// Is allows to match a QuotaError with errors.Is(err, ErrQuotaExceeded).
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// bucket is a token bucket that holds up to limit tokens, and refills them
+// at a constant rate over window.
+type bucket struct {
+	limit  int
+	window time.Duration
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit int, window time.Duration, now time.Time) bucket {
+	return bucket{limit: limit, window: window, tokens: float64(limit), last: now}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if b.limit <= 0 {
+		return
+	}
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens += float64(b.limit) * float64(elapsed) / float64(b.window)
+	if b.tokens > float64(b.limit) {
+		b.tokens = float64(b.limit)
+	}
+	b.last = now
+}
+
+// wait returns how long to wait until a token is available. A bucket with no
+// limit never waits.
+func (b *bucket) wait() time.Duration {
+	if b.limit <= 0 || b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.window) / float64(b.limit))
+}
+
+func (b *bucket) take() {
+	if b.limit > 0 {
+		b.tokens--
+	}
+}
+
+// dailyWindow is a fixed window that allows up to limit calls per UTC day.
+type dailyWindow struct {
+	limit int
+	used  int
+	// end is the next midnight UTC, when used is reset.
+	end time.Time
+}
+
+func newDailyWindow(limit int, now time.Time) dailyWindow {
+	return dailyWindow{limit: limit, end: nextMidnightUTC(now)}
+}
+
+// nextMidnightUTC returns the first midnight UTC after t.
+func nextMidnightUTC(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// refill resets the window if a new day has started.
+func (w *dailyWindow) refill(now time.Time) {
+	if !now.Before(w.end) {
+		w.used = 0
+		w.end = nextMidnightUTC(now)
+	}
+}
+
+// wait returns how long to wait until a call is allowed. A window with no
+// limit never waits.
+func (w *dailyWindow) wait(now time.Time) time.Duration {
+	if w.limit <= 0 || w.used < w.limit {
+		return 0
+	}
+	return w.end.Sub(now)
+}
+
+func (w *dailyWindow) take() {
+	if w.limit > 0 {
+		w.used++
+	}
+}
+
+// RateLimiter is a client-side rate limiter that keeps the calls within a
+// per-minute and a per-day budget, like the quotas enforced by
+// OpenWeatherMap's subscriptions. It also counts the calls that it let
+// through. A RateLimiter is safe for concurrent use, and can be shared by
+// several clients to enforce a common budget.
+type RateLimiter struct {
+	mu        sync.Mutex
+	perMinute bucket
+	perDay    dailyWindow
+	block     bool
+	calls     uint64
+	// now returns the current time. It is replaced by the tests.
+	now func() time.Time
+}
+
+// NewRateLimiter returns a rate limiter that allows up to perMinute calls per
+// minute and up to perDay calls per day. A limit of zero means no limit for
+// that window. If block is true, calls over budget wait until they are
+// allowed, otherwise they fail immediately with a *QuotaError.
+//
+// The per-minute budget is a token bucket, that allows bursts of up to
+// perMinute calls and refills continuously. The per-day budget is instead a
+// fixed window that resets at midnight UTC, like OpenWeatherMap's daily
+// quota, so that no more than perDay calls are made within a UTC day.
+func NewRateLimiter(perMinute, perDay int, block bool) *RateLimiter {
+	now := time.Now()
+	return &RateLimiter{
+		perMinute: newBucket(perMinute, time.Minute, now),
+		perDay:    newDailyWindow(perDay, now),
+		block:     block,
+		now:       time.Now,
+	}
+}
+
+// Wait takes a call out of the budget. If the budget is exhausted, it either
+// waits until a call is allowed or the context is done, or returns a
+// *QuotaError, depending on how the limiter was created.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := l.now()
+		l.perMinute.refill(now)
+		l.perDay.refill(now)
+		var qerr QuotaError
+		if wait := l.perDay.wait(now); wait > 0 {
+			qerr = QuotaError{Window: 24 * time.Hour, Limit: l.perDay.limit, RetryAfter: wait}
+		} else if wait := l.perMinute.wait(); wait > 0 {
+			qerr = QuotaError{Window: time.Minute, Limit: l.perMinute.limit, RetryAfter: wait}
+		} else {
+			l.perMinute.take()
+			l.perDay.take()
+			l.calls++
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		if !l.block {
+			return &qerr
+		}
+		timer := time.NewTimer(qerr.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Calls returns the number of calls that the limiter let through so far.
+func (l *RateLimiter) Calls() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.calls
+}
@@ -0,0 +1,164 @@
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBucket(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBucket(60, time.Minute, start)
+	// the bucket starts full, allowing a burst of up to limit calls.
+	for i := 0; i < 60; i++ {
+		if wait := b.wait(); wait != 0 {
+			t.Fatalf("call %d: got wait %s, want 0", i, wait)
+		}
+		b.take()
+	}
+	if got, want := b.wait(), time.Second; got != want {
+		t.Errorf("empty bucket: got wait %s, want %s", got, want)
+	}
+	// it refills at limit tokens per window.
+	b.refill(start.Add(500 * time.Millisecond))
+	if got, want := b.wait(), 500*time.Millisecond; got != want {
+		t.Errorf("after half a token: got wait %s, want %s", got, want)
+	}
+	b.refill(start.Add(10 * time.Second))
+	if got, want := int(b.tokens), 10; got != want {
+		t.Errorf("after 10s: got %d tokens, want %d", got, want)
+	}
+	// and never above the limit.
+	b.refill(start.Add(time.Hour))
+	if got, want := b.tokens, 60.0; got != want {
+		t.Errorf("after an hour: got %v tokens, want %v", got, want)
+	}
+
+	unlimited := newBucket(0, time.Minute, start)
+	for i := 0; i < 1000; i++ {
+		unlimited.take()
+	}
+	if wait := unlimited.wait(); wait != 0 {
+		t.Errorf("unlimited bucket: got wait %s, want 0", wait)
+	}
+}
+
+func TestDailyWindow(t *testing.T) {
+	for _, tc := range []struct {
+		t, want time.Time
+	}{
+		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 2, 28, 13, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// the day is the UTC one, whatever the time zone of t.
+		{time.Date(2024, 1, 1, 23, 0, 0, 0, time.FixedZone("", -3*3600)), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+	} {
+		if got := nextMidnightUTC(tc.t); !got.Equal(tc.want) {
+			t.Errorf("nextMidnightUTC(%s): got %s, want %s", tc.t, got, tc.want)
+		}
+	}
+
+	now := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	w := newDailyWindow(3, now)
+	for i := 0; i < 3; i++ {
+		if wait := w.wait(now); wait != 0 {
+			t.Fatalf("call %d: got wait %s, want 0", i, wait)
+		}
+		w.take()
+	}
+	if got, want := w.wait(now), 2*time.Hour; got != want {
+		t.Errorf("exhausted window: got wait %s, want %s", got, want)
+	}
+	// the budget does not refill during the day.
+	now = now.Add(time.Hour + 59*time.Minute)
+	w.refill(now)
+	if got, want := w.wait(now), time.Minute; got != want {
+		t.Errorf("before midnight: got wait %s, want %s", got, want)
+	}
+	// and resets at midnight UTC.
+	now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	w.refill(now)
+	if wait := w.wait(now); wait != 0 {
+		t.Errorf("after midnight: got wait %s, want 0", wait)
+	}
+	if got, want := w.end, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("after midnight: got end %s, want %s", got, want)
+	}
+}
+
+func TestRateLimiterNonBlocking(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(2, 3, false)
+	l.perMinute = newBucket(2, time.Minute, now)
+	l.perDay = newDailyWindow(3, now)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	err := l.Wait(ctx)
+	var qerr *QuotaError
+	if !errors.As(err, &qerr) {
+		t.Fatalf("got error %v, want a *QuotaError", err)
+	}
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Error("the error does not match ErrQuotaExceeded")
+	}
+	if qerr.Window != time.Minute || qerr.Limit != 2 || qerr.RetryAfter != 30*time.Second {
+		t.Errorf("got %+v, want the per-minute window, limit 2, retry after 30s", qerr)
+	}
+
+	now = now.Add(time.Minute)
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("third call: %v", err)
+	}
+	now = now.Add(time.Minute)
+	err = l.Wait(ctx)
+	if !errors.As(err, &qerr) || !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("got error %v, want a *QuotaError", err)
+	}
+	if qerr.Window != 24*time.Hour || qerr.Limit != 3 || qerr.RetryAfter != 58*time.Minute {
+		t.Errorf("got %+v, want the per-day window, limit 3, retry after 58m", qerr)
+	}
+
+	now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if err := l.Wait(ctx); err != nil {
+		t.Errorf("after midnight: %v", err)
+	}
+	if got, want := l.Calls(), uint64(4); got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestRateLimiterBlocking(t *testing.T) {
+	l := NewRateLimiter(600, 0, true)
+	ctx := context.Background()
+	start := time.Now()
+	// the burst goes through at once, the next call waits for a token,
+	// i.e. 100ms.
+	for i := 0; i < 601; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("the call over budget did not wait: took %s", elapsed)
+	}
+	if got, want := l.Calls(), uint64(601); got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+
+	// a context that is done stops the wait.
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	l = NewRateLimiter(1, 0, true)
+	l.Wait(ctx)
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
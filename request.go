@@ -1,66 +1,61 @@
 package openweathermap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"os"
+	"sort"
 	"strconv"
 	"strings"
-)
 
-// base URL for the One Call API 3.0, see https://openweathermap.org/api/one-call-3
-const baseURL = "https://api.openweathermap.org/data/3.0/onecall"
+	"github.com/insomniacslk/openweathermap/internal/apiutil"
+)
 
-// Request uses OpenWeatherMap's One Call API 3.0.
+// Request uses OpenWeatherMap's One Call API 3.0, see
+// https://openweathermap.org/api/one-call-3 .
+// If debug is true, requests are logged to stderr.
+//
+// Deprecated: use Client.Request instead.
 func Request(appID string, lat, lon float64, exclude []Exclude, units Units, lang Lang, debug bool) (*Weather, error) {
-	u, err := url.Parse(baseURL)
+	c := NewClient(appID)
+	if debug {
+		c.Logger = DebugLogger()
+	}
+	return c.Request(lat, lon, exclude, units, lang)
+}
+
+// Request uses OpenWeatherMap's One Call API 3.0. If units or lang are empty,
+// the client's defaults are used.
+func (c *Client) Request(lat, lon float64, exclude []Exclude, units Units, lang Lang) (*Weather, error) {
+	return c.RequestContext(context.Background(), lat, lon, exclude, units, lang)
+}
+
+// RequestContext is like Request, but the request is bound to the given
+// context.
+func (c *Client) RequestContext(ctx context.Context, lat, lon float64, exclude []Exclude, units Units, lang Lang) (*Weather, error) {
+	u, err := c.URL(OneCallAPI, "")
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, err
 	}
 	q := u.Query()
-	q.Set("lat", strconv.FormatFloat(lat, 'f', 3, 32))
-	q.Set("lon", strconv.FormatFloat(lon, 'f', 3, 32))
-	q.Set("appid", appID)
+	setCoordinates(q, lat, lon)
 	if len(exclude) != 0 {
-		var excludes string
 		excludeStrings := make([]string, 0, len(exclude))
 		for _, e := range exclude {
 			excludeStrings = append(excludeStrings, string(e))
 		}
-		excludes = strings.Join(excludeStrings, ",")
-		q.Set("exclude", excludes)
-	}
-	if units != "" {
-		q.Set("units", string(units))
-	}
-	if lang != "" {
-		q.Set("lang", string(lang))
+		// sort them so that the same excludes in any order share the cache
+		// entry.
+		sort.Strings(excludeStrings)
+		q.Set("exclude", strings.Join(excludeStrings, ","))
 	}
+	c.setUnitsLang(q, units, lang)
 	u.RawQuery = q.Encode()
 
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return nil, fmt.Errorf("HTTP GET failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := c.GetContext(ctx, u)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read HTTP body: %w", err)
-	}
-	if resp.StatusCode != 200 {
-		var fresp OneCallAPIFailedResponse
-		if err := json.Unmarshal(body, &fresp); err != nil {
-			return nil, fmt.Errorf("HTTP GET returned status '%s', and could not unmarshal response message: %w", resp.Status, err)
-		}
-		return nil, fmt.Errorf("Request failed with %d: %s", fresp.Cod, fresp.Message)
-	}
-
-	if debug {
-		fmt.Fprintf(os.Stderr, "%s\n", string(body))
+		return nil, err
 	}
 	var apiResp Weather
 	if err := json.Unmarshal(body, &apiResp); err != nil {
@@ -69,3 +64,16 @@ func Request(appID string, lat, lon float64, exclude []Exclude, units Units, lan
 
 	return &apiResp, nil
 }
+
+// setCoordinates sets latitude and longitude in a One Call API query. They
+// are rounded to three decimal places, i.e. about a hundred meters.
+func setCoordinates(q url.Values, lat, lon float64) {
+	q.Set("lat", strconv.FormatFloat(lat, 'f', 3, 32))
+	q.Set("lon", strconv.FormatFloat(lon, 'f', 3, 32))
+}
+
+// setUnitsLang sets units and language in a query, falling back to the
+// client's defaults if they are empty.
+func (c *Client) setUnitsLang(q url.Values, units Units, lang Lang) {
+	apiutil.SetUnitsLang(q, units, lang, c.Units, c.Lang)
+}
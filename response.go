@@ -2,28 +2,38 @@ package openweathermap
 
 // Weather maps to a JSON response from OpenWeatherMap's OneCallAPI.
 type Weather struct {
-	Lat            float64              `json:"lat"`
-	Lon            float64              `json:"lon"`
-	Timezone       string               `json:"timezone"`
-	TimezoneOffset int                  `json:"timezone_offset"`
-	Current        *PointWeatherSummary `json:"current"`
-	Minutely       []struct {
-		Dt            int64 `json:"dt"`
-		Precipitation int   `json:"precipitation"`
-	} `json:"minutely"`
-	Hourly  []PointWeatherSummary `json:"hourly"`
-	Daily   []DailyWeatherSummary `json:"daily"`
-	Summary string                `json:"summary"`
-	Alerts  []struct {
-		SenderName  string `json:"sender_name"`
-		Event       string `json:"event"`
-		Start       int64  `json:"start"`
-		End         int64  `json:"end"`
-		Description string `json:"description"`
-	}
+	Lat            float64                 `json:"lat"`
+	Lon            float64                 `json:"lon"`
+	Timezone       string                  `json:"timezone"`
+	TimezoneOffset int                     `json:"timezone_offset"`
+	Current        *PointWeatherSummary    `json:"current"`
+	Minutely       []MinutelyPrecipitation `json:"minutely"`
+	Hourly         []PointWeatherSummary   `json:"hourly"`
+	Daily          []DailyWeatherSummary   `json:"daily"`
+	Summary        string                  `json:"summary"`
+	Alerts         []Alert                 `json:"alerts"`
+}
+
+// MinutelyPrecipitation is a subfield of Weather, with the precipitation
+// forecast for a single minute.
+type MinutelyPrecipitation struct {
+	Dt            int64   `json:"dt"`
+	Precipitation float64 `json:"precipitation"`
+}
+
+// Alert is a subfield of Weather, with a national weather alert.
+type Alert struct {
+	SenderName  string   `json:"sender_name"`
+	Event       string   `json:"event"`
+	Start       int64    `json:"start"`
+	End         int64    `json:"end"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
 }
 
 // OneCallAPIFailedResponse is used when a request has failed.
+//
+// Deprecated: failed requests are reported as *APIError.
 type OneCallAPIFailedResponse struct {
 	Cod     int    `json:"cod"`
 	Message string `json:"message"`
@@ -35,10 +45,10 @@ type PointWeatherSummary struct {
 	Temp      float64 `json:"temp"`
 	FeelsLike float64 `json:"feels_like"`
 	Rain      struct {
-		OneHour *float64 `json:"1H"`
+		OneHour *float64 `json:"1h"`
 	} `json:"rain"`
 	Snow struct {
-		OneHour *float64 `json:"1H"`
+		OneHour *float64 `json:"1h"`
 	} `json:"snow"`
 }
 
@@ -59,10 +69,10 @@ type CommonWeatherSummary struct {
 	WindGust   *float64 `json:"wind_gust"`
 	Pop        float64  `json:"pop"`
 	Weather    []struct {
-		ID          int    `json:"id"`
-		Main        string `json:"main"`
-		Description string `json:"description"`
-		Icon        string `json:"icon"`
+		ID          ConditionCode `json:"id"`
+		Main        string        `json:"main"`
+		Description string        `json:"description"`
+		Icon        string        `json:"icon"`
 	} `json:"weather"`
 }
 
@@ -83,6 +93,10 @@ type DailyWeatherSummary struct {
 		Eve   float64 `json:"eve"`
 		Morn  float64 `json:"morn"`
 	} `json:"feels_like"`
-	Rain *float64 `json:"rain"`
-	Snow *float64 `json:"snow"`
+	Moonrise  int64    `json:"moonrise"`
+	Moonset   int64    `json:"moonset"`
+	MoonPhase float64  `json:"moon_phase"`
+	Summary   string   `json:"summary"`
+	Rain      *float64 `json:"rain"`
+	Snow      *float64 `json:"snow"`
 }
@@ -0,0 +1,82 @@
+package openweathermap
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func loadWeather(t *testing.T, name string) *Weather {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w Weather
+	if err := json.Unmarshal(data, &w); err != nil {
+		t.Fatalf("failed to decode %s: %v", name, err)
+	}
+	return &w
+}
+
+func TestWeatherDecoding(t *testing.T) {
+	w := loadWeather(t, "testdata/onecall.json")
+
+	if w.Timezone != "America/Chicago" || w.TimezoneOffset != -18000 {
+		t.Errorf("got time zone %q %d, want America/Chicago -18000", w.Timezone, w.TimezoneOffset)
+	}
+
+	if w.Current == nil {
+		t.Fatal("current is nil")
+	}
+	if w.Current.Rain.OneHour == nil || *w.Current.Rain.OneHour != 0.21 {
+		t.Errorf("current.rain.1h: got %v, want 0.21", w.Current.Rain.OneHour)
+	}
+	if w.Current.Weather[0].ID != LightRain {
+		t.Errorf("current.weather.id: got %d, want %d", w.Current.Weather[0].ID, LightRain)
+	}
+
+	if len(w.Minutely) != 2 {
+		t.Fatalf("minutely: got %d items, want 2", len(w.Minutely))
+	}
+	if got := w.Minutely[1].Precipitation; got != 0.2266 {
+		t.Errorf("minutely.precipitation: got %v, want 0.2266", got)
+	}
+
+	if len(w.Hourly) != 2 {
+		t.Fatalf("hourly: got %d items, want 2", len(w.Hourly))
+	}
+	if r := w.Hourly[0].Rain.OneHour; r == nil || *r != 0.38 {
+		t.Errorf("hourly[0].rain.1h: got %v, want 0.38", r)
+	}
+	if s := w.Hourly[0].Snow.OneHour; s != nil {
+		t.Errorf("hourly[0].snow.1h: got %v, want nil", *s)
+	}
+	if s := w.Hourly[1].Snow.OneHour; s == nil || *s != 0.25 {
+		t.Errorf("hourly[1].snow.1h: got %v, want 0.25", s)
+	}
+
+	if len(w.Daily) != 1 {
+		t.Fatalf("daily: got %d items, want 1", len(w.Daily))
+	}
+	d := w.Daily[0]
+	if d.Moonrise != 1684941060 || d.Moonset != 1684905480 {
+		t.Errorf("daily moonrise/moonset: got %d/%d, want 1684941060/1684905480", d.Moonrise, d.Moonset)
+	}
+	if d.MoonPhase != 0.16 {
+		t.Errorf("daily.moon_phase: got %v, want 0.16", d.MoonPhase)
+	}
+	if d.Summary != "Expect a day of partly cloudy with rain" {
+		t.Errorf("daily.summary: got %q", d.Summary)
+	}
+	if d.Rain == nil || *d.Rain != 0.15 {
+		t.Errorf("daily.rain: got %v, want 0.15", d.Rain)
+	}
+
+	if len(w.Alerts) != 1 {
+		t.Fatalf("alerts: got %d items, want 1", len(w.Alerts))
+	}
+	if tags := w.Alerts[0].Tags; len(tags) != 1 || tags[0] != "Extreme temperature value" {
+		t.Errorf("alerts.tags: got %q, want [Extreme temperature value]", tags)
+	}
+}
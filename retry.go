@@ -0,0 +1,116 @@
+package openweathermap
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how failed requests are retried. Only transient
+// failures are retried: network errors, 429 Too Many Requests and the 5xx
+// statuses that signal a temporary server-side problem. All the API calls
+// are GET requests, hence safe to repeat.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. A value lower than 2 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the time to wait before the first retry. If zero,
+	// DefaultRetryPolicy.InitialBackoff is used.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the time to wait between two attempts, unless the
+	// server asks for a longer wait via the Retry-After header, up to
+	// MaxRetryAfter. If zero, DefaultRetryPolicy.MaxBackoff is used.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the backoff grows by after every attempt. If
+	// lower than 1, DefaultRetryPolicy.Multiplier is used.
+	Multiplier float64
+	// Jitter randomizes each backoff by up to the given fraction in either
+	// direction, e.g. 0.2 means +/-20%, so that many clients failing at the
+	// same time do not retry in lockstep.
+	Jitter float64
+	// MaxRetryAfter caps the wait that the server can ask for via the
+	// Retry-After header: if it asks for longer, the error is returned right
+	// away rather than blocking the caller. If zero,
+	// DefaultRetryPolicy.MaxRetryAfter is used.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable retry policy, that makes up to three
+// attempts over a few seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	MaxRetryAfter:  time.Minute,
+}
+
+// backoff returns the time to wait after the given failed attempt, starting
+// from 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, maxBackoff, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = DefaultRetryPolicy.InitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	if multiplier < 1 {
+		multiplier = DefaultRetryPolicy.Multiplier
+	}
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if d > float64(maxBackoff) {
+		d = float64(maxBackoff)
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(d)
+}
+
+// maxRetryAfter returns the longest wait that the server can ask for.
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter <= 0 {
+		return DefaultRetryPolicy.MaxRetryAfter
+	}
+	return p.MaxRetryAfter
+}
+
+// isRetryableStatus returns true if a response with the given status code
+// reports a transient failure.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date. It returns zero if the header is
+// missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package openweathermap
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second, Multiplier: 2}
+	for _, tc := range []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{10, 10 * time.Second},
+	} {
+		if got := p.backoff(tc.attempt); got != tc.want {
+			t.Errorf("attempt %d: got %s, want %s", tc.attempt, got, tc.want)
+		}
+	}
+
+	// the zero policy uses the defaults.
+	var zero RetryPolicy
+	if got, want := zero.backoff(1), DefaultRetryPolicy.InitialBackoff; got != want {
+		t.Errorf("zero policy, attempt 1: got %s, want %s", got, want)
+	}
+	if got, want := zero.backoff(2), 2*DefaultRetryPolicy.InitialBackoff; got != want {
+		t.Errorf("zero policy, attempt 2: got %s, want %s", got, want)
+	}
+	if got, want := zero.backoff(100), DefaultRetryPolicy.MaxBackoff; got != want {
+		t.Errorf("zero policy, attempt 100: got %s, want %s", got, want)
+	}
+
+	p.Jitter = 0.2
+	for i := 0; i < 1000; i++ {
+		if got := p.backoff(2); got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("jittered backoff %s out of [1.6s, 2.4s]", got)
+		}
+		if got := p.backoff(10); got < 8*time.Second || got > 12*time.Second {
+			t.Fatalf("jittered capped backoff %s out of [8s, 12s]", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"120", 2 * time.Minute},
+		{"-5", 0},
+		{"1.5", 0},
+		{"soon", 0},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second},
+		{"Mon, 01 Jan 2024 11:59:00 GMT", 0},
+		{"Monday, 01-Jan-24 12:01:00 GMT", time.Minute},
+	} {
+		if got := parseRetryAfter(tc.value, now); got != tc.want {
+			t.Errorf("%q: got %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	for code, want := range map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusNotImplemented:      false,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	} {
+		if got := isRetryableStatus(code); got != want {
+			t.Errorf("%d: got %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestRetryAfterCap(t *testing.T) {
+	for _, tc := range []struct {
+		retryAfter string
+		wantCalls  int
+	}{
+		// within the cap: retried after the requested wait.
+		{"1", 2},
+		// over the cap: the error is returned right away.
+		{"3600", 1},
+	} {
+		var calls int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", tc.retryAfter)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"cod":503,"message":"busy"}`))
+		}))
+		c := NewClient("key")
+		c.Endpoints = map[API]string{OneCallAPI: srv.URL}
+		c.Retry = &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxRetryAfter: 2 * time.Second}
+		start := time.Now()
+		_, err := c.Request(1, 2, nil, Metric, "")
+		srv.Close()
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Retry-After %s: got error %v, want an *APIError", tc.retryAfter, err)
+		}
+		if calls != tc.wantCalls {
+			t.Errorf("Retry-After %s: got %d calls, want %d", tc.retryAfter, calls, tc.wantCalls)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Retry-After %s: took %s", tc.retryAfter, elapsed)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Package solar calculates the position of the sun and the times of
+// sunrise, sunset and twilight for a given location, without calling any
+// API. It implements the NOAA solar calculator algorithms described at
+// https://gml.noaa.gov/grad/solcalc/calcdetails.html , which are accurate
+// within a minute for latitudes between +/- 72 degrees.
+package solar
+
+import (
+	"math"
+	"time"
+)
+
+// Elevations of the center of the sun, in degrees, that define the solar
+// events.
+const (
+	// SunriseElevation accounts for the atmospheric refraction and for the
+	// apparent radius of the sun.
+	SunriseElevation      = -0.833
+	CivilElevation        = -6.0
+	NauticalElevation     = -12.0
+	AstronomicalElevation = -18.0
+)
+
+// Times contains the solar events of a day. Events that do not happen on
+// that day, e.g. sunrise and sunset during the polar night, are set to the
+// zero time.
+type Times struct {
+	AstronomicalDawn time.Time
+	NauticalDawn     time.Time
+	CivilDawn        time.Time
+	Sunrise          time.Time
+	SolarNoon        time.Time
+	Sunset           time.Time
+	CivilDusk        time.Time
+	NauticalDusk     time.Time
+	AstronomicalDusk time.Time
+
+	// polarDay is true if the sun is up at solar noon. When there is no
+	// sunrise nor sunset, it tells the polar day from the polar night.
+	polarDay bool
+}
+
+// DayLength returns the time between sunrise and sunset. During the polar
+// day it returns 24 hours, and during the polar night it returns zero.
+func (t *Times) DayLength() time.Duration {
+	if t.Sunrise.IsZero() || t.Sunset.IsZero() {
+		if t.polarDay {
+			return 24 * time.Hour
+		}
+		return 0
+	}
+	return t.Sunset.Sub(t.Sunrise)
+}
+
+// radians and degrees conversion helpers.
+func rad(deg float64) float64 { return deg * math.Pi / 180 }
+func deg(rad float64) float64 { return rad * 180 / math.Pi }
+
+// sunParams holds the values of the sun's apparent motion used by all the
+// calculations, at a given time.
+type sunParams struct {
+	// declination of the sun in degrees.
+	declination float64
+	// equation of time in minutes.
+	eqTime float64
+}
+
+// julianCentury returns the number of Julian centuries since J2000.0.
+func julianCentury(t time.Time) float64 {
+	jd := float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+	return (jd - 2451545) / 36525
+}
+
+func params(t time.Time) sunParams {
+	jc := julianCentury(t)
+	meanLong := math.Mod(280.46646+jc*(36000.76983+jc*0.0003032), 360)
+	meanAnom := 357.52911 + jc*(35999.05029-0.0001537*jc)
+	eccent := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+	eqCenter := math.Sin(rad(meanAnom))*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(rad(2*meanAnom))*(0.019993-0.000101*jc) +
+		math.Sin(rad(3*meanAnom))*0.000289
+	trueLong := meanLong + eqCenter
+	omega := 125.04 - 1934.136*jc
+	appLong := trueLong - 0.00569 - 0.00478*math.Sin(rad(omega))
+	meanObliq := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliq := meanObliq + 0.00256*math.Cos(rad(omega))
+	declination := deg(math.Asin(math.Sin(rad(obliq)) * math.Sin(rad(appLong))))
+	y := math.Pow(math.Tan(rad(obliq/2)), 2)
+	eqTime := 4 * deg(y*math.Sin(2*rad(meanLong))-
+		2*eccent*math.Sin(rad(meanAnom))+
+		4*eccent*y*math.Sin(rad(meanAnom))*math.Cos(2*rad(meanLong))-
+		0.5*y*y*math.Sin(4*rad(meanLong))-
+		1.25*eccent*eccent*math.Sin(2*rad(meanAnom)))
+	return sunParams{declination: declination, eqTime: eqTime}
+}
+
+// Position returns the elevation above the horizon and the azimuth,
+// clockwise from north, of the sun in degrees, as seen at the given time
+// from the given location. The elevation is corrected for the atmospheric
+// refraction.
+func Position(t time.Time, lat, lon float64) (elevation, azimuth float64) {
+	elevation, azimuth = position(t, lat, lon)
+	return elevation + refraction(elevation), azimuth
+}
+
+// position returns the geometric elevation and the azimuth of the sun.
+func position(t time.Time, lat, lon float64) (elevation, azimuth float64) {
+	p := params(t)
+	utc := t.UTC()
+	minutes := float64(utc.Hour()*60+utc.Minute()) + float64(utc.Second())/60 + float64(utc.Nanosecond())/6e10
+	trueSolarTime := math.Mod(minutes+p.eqTime+4*lon, 1440)
+	hourAngle := trueSolarTime/4 - 180
+	if hourAngle < -180 {
+		hourAngle += 360
+	}
+	cosZenith := math.Sin(rad(lat))*math.Sin(rad(p.declination)) +
+		math.Cos(rad(lat))*math.Cos(rad(p.declination))*math.Cos(rad(hourAngle))
+	zenith := deg(math.Acos(math.Max(-1, math.Min(1, cosZenith))))
+	elevation = 90 - zenith
+
+	denom := math.Cos(rad(lat)) * math.Sin(rad(zenith))
+	if math.Abs(denom) < 1e-12 {
+		// at the poles or with the sun at the zenith the azimuth is
+		// undefined.
+		return elevation, 0
+	}
+	cosAz := (math.Sin(rad(lat))*math.Cos(rad(zenith)) - math.Sin(rad(p.declination))) / denom
+	az := deg(math.Acos(math.Max(-1, math.Min(1, cosAz))))
+	if hourAngle > 0 {
+		azimuth = math.Mod(az+180, 360)
+	} else {
+		azimuth = math.Mod(540-az, 360)
+	}
+	return elevation, azimuth
+}
+
+// refraction returns the approximate atmospheric refraction in degrees for
+// the given geometric elevation.
+func refraction(elevation float64) float64 {
+	var r float64 // in arc seconds
+	switch te := math.Tan(rad(elevation)); {
+	case elevation > 85:
+		return 0
+	case elevation > 5:
+		r = 58.1/te - 0.07/(te*te*te) + 0.000086/math.Pow(te, 5)
+	case elevation > -0.575:
+		r = 1735 + elevation*(-518.2+elevation*(103.4+elevation*(-12.79+elevation*0.711)))
+	default:
+		r = -20.772 / te
+	}
+	return r / 3600
+}
+
+// solarNoon returns the solar noon on the given UTC day.
+func solarNoon(day time.Time, lon float64) time.Time {
+	// start from the mean solar noon, then refine with the equation of
+	// time at that moment.
+	noon := day.Add(time.Duration((720 - 4*lon) * float64(time.Minute)))
+	p := params(noon)
+	return day.Add(time.Duration((720 - 4*lon - p.eqTime) * float64(time.Minute)))
+}
+
+// event returns the time on the given UTC day when the sun crosses the
+// given elevation, in the morning if rising is true or in the evening
+// otherwise. It returns false if the sun does not cross that elevation.
+func event(day time.Time, lat, lon, elevation float64, rising bool) (time.Time, bool) {
+	t := solarNoon(day, lon)
+	// the declination changes during the day, so refine the estimate a few
+	// times.
+	for i := 0; i < 3; i++ {
+		p := params(t)
+		cosHA := (math.Sin(rad(elevation)) - math.Sin(rad(lat))*math.Sin(rad(p.declination))) /
+			(math.Cos(rad(lat)) * math.Cos(rad(p.declination)))
+		if cosHA < -1 || cosHA > 1 {
+			return time.Time{}, false
+		}
+		hourAngle := deg(math.Acos(cosHA))
+		if rising {
+			hourAngle = -hourAngle
+		}
+		minutes := 720 - 4*lon - p.eqTime + 4*hourAngle
+		t = day.Add(time.Duration(minutes * float64(time.Minute)))
+	}
+	return t, true
+}
+
+// Calculate returns the solar events on the given day at the given location.
+// The day is the calendar date of date in its location, and the returned
+// times are in that location too.
+func Calculate(date time.Time, lat, lon float64) Times {
+	loc := date.Location()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	noon := solarNoon(day, lon)
+	noonElevation, _ := position(noon, lat, lon)
+	times := Times{
+		SolarNoon: noon.In(loc),
+		polarDay:  noonElevation > SunriseElevation,
+	}
+	for _, e := range []struct {
+		elevation  float64
+		dawn, dusk *time.Time
+	}{
+		{SunriseElevation, &times.Sunrise, &times.Sunset},
+		{CivilElevation, &times.CivilDawn, &times.CivilDusk},
+		{NauticalElevation, &times.NauticalDawn, &times.NauticalDusk},
+		{AstronomicalElevation, &times.AstronomicalDawn, &times.AstronomicalDusk},
+	} {
+		if t, ok := event(day, lat, lon, e.elevation, true); ok {
+			*e.dawn = t.In(loc)
+		}
+		if t, ok := event(day, lat, lon, e.elevation, false); ok {
+			*e.dusk = t.In(loc)
+		}
+	}
+	return times
+}
+
+// IsDaytime returns true if the sun is above the horizon at the given time
+// and location, i.e. between sunrise and sunset.
+func IsDaytime(t time.Time, lat, lon float64) bool {
+	elevation, _ := position(t, lat, lon)
+	return elevation > SunriseElevation
+}
@@ -0,0 +1,145 @@
+package solar
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// clock parses a local time of the day of date, as "15:04".
+func clock(t *testing.T, date time.Time, value string) time.Time {
+	t.Helper()
+	if value == "" {
+		return time.Time{}
+	}
+	c, err := time.Parse("15:04", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), c.Hour(), c.Minute(), 0, 0, date.Location())
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("tz database not available: %v", err)
+	}
+	return loc
+}
+
+// TestCalculate checks the solar events against the NOAA solar calculator,
+// https://gml.noaa.gov/grad/solcalc/ , whose times are rounded to the
+// minute.
+func TestCalculate(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+	newYork := loadLocation(t, "America/New_York")
+	for _, tc := range []struct {
+		name     string
+		date     time.Time
+		lat, lon float64
+		// astronomical, nautical and civil dawn, sunrise, solar noon,
+		// sunset, and civil, nautical and astronomical dusk. An empty
+		// string means that the event does not happen.
+		want [9]string
+	}{
+		{"London, summer solstice", time.Date(2024, 6, 21, 0, 0, 0, 0, london), 51.5074, -0.1278,
+			[9]string{"", "02:41", "03:55", "04:43", "13:02", "21:22", "22:09", "23:24", ""}},
+		{"London, winter solstice", time.Date(2024, 12, 21, 0, 0, 0, 0, london), 51.5074, -0.1278,
+			[9]string{"06:00", "06:40", "07:24", "08:04", "11:59", "15:54", "16:34", "17:17", "17:58"}},
+		{"New York, equinox", time.Date(2024, 3, 20, 0, 0, 0, 0, newYork), 40.7128, -74.0060,
+			[9]string{"05:27", "05:59", "06:31", "06:58", "13:03", "19:09", "19:36", "20:08", "20:41"}},
+	} {
+		times := Calculate(tc.date, tc.lat, tc.lon)
+		got := [9]time.Time{
+			times.AstronomicalDawn, times.NauticalDawn, times.CivilDawn,
+			times.Sunrise, times.SolarNoon, times.Sunset,
+			times.CivilDusk, times.NauticalDusk, times.AstronomicalDusk,
+		}
+		for i, value := range tc.want {
+			want := clock(t, tc.date, value)
+			if want.IsZero() || got[i].IsZero() {
+				if !want.IsZero() || !got[i].IsZero() {
+					t.Errorf("%s, event %d: got %s, want %q", tc.name, i, got[i], value)
+				}
+				continue
+			}
+			if d := got[i].Sub(want); d < -time.Minute || d > time.Minute {
+				t.Errorf("%s, event %d: got %s, want %s", tc.name, i, got[i].Format("15:04:05"), value)
+			}
+			if got[i].Location() != tc.date.Location() {
+				t.Errorf("%s, event %d: got location %s, want %s", tc.name, i, got[i].Location(), tc.date.Location())
+			}
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+	const lat, lon = 51.5074, -0.1278
+	summer := Calculate(time.Date(2024, 6, 21, 0, 0, 0, 0, london), lat, lon)
+	winter := Calculate(time.Date(2024, 12, 21, 0, 0, 0, 0, london), lat, lon)
+	for _, tc := range []struct {
+		name               string
+		t                  time.Time
+		elevation, azimuth float64
+	}{
+		// at solar noon the sun is due south, at 90 - lat + declination.
+		{"summer noon", summer.SolarNoon, 61.94, 180},
+		{"winter noon", winter.SolarNoon, 15.11, 180},
+		{"summer sunrise", summer.Sunrise, -0.44, 48.9},
+		{"summer sunset", summer.Sunset, -0.44, 311.1},
+		{"winter sunrise", winter.Sunrise, -0.44, 128.4},
+		{"winter sunset", winter.Sunset, -0.44, 231.6},
+	} {
+		elevation, azimuth := Position(tc.t, lat, lon)
+		if math.Abs(elevation-tc.elevation) > 0.1 || math.Abs(azimuth-tc.azimuth) > 0.5 {
+			t.Errorf("%s: got elevation %.2f, azimuth %.2f, want %.2f, %.2f",
+				tc.name, elevation, azimuth, tc.elevation, tc.azimuth)
+		}
+	}
+	if !IsDaytime(summer.SolarNoon, lat, lon) {
+		t.Error("not daytime at solar noon")
+	}
+	if IsDaytime(summer.SolarNoon.Add(12*time.Hour), lat, lon) {
+		t.Error("daytime at midnight")
+	}
+}
+
+func TestPolarDayNight(t *testing.T) {
+	oslo := loadLocation(t, "Europe/Oslo")
+	// Tromsø, above the arctic circle.
+	const lat, lon = 69.6492, 18.9553
+
+	midsummer := Calculate(time.Date(2024, 6, 21, 0, 0, 0, 0, oslo), lat, lon)
+	if !midsummer.Sunrise.IsZero() || !midsummer.Sunset.IsZero() {
+		t.Errorf("polar day: got sunrise %s and sunset %s, want none", midsummer.Sunrise, midsummer.Sunset)
+	}
+	if got := midsummer.DayLength(); got != 24*time.Hour {
+		t.Errorf("polar day: got day length %s, want 24h", got)
+	}
+	if midsummer.SolarNoon.IsZero() {
+		t.Error("polar day: no solar noon")
+	}
+
+	midwinter := Calculate(time.Date(2024, 12, 21, 0, 0, 0, 0, oslo), lat, lon)
+	if !midwinter.Sunrise.IsZero() || !midwinter.Sunset.IsZero() {
+		t.Errorf("polar night: got sunrise %s and sunset %s, want none", midwinter.Sunrise, midwinter.Sunset)
+	}
+	if got := midwinter.DayLength(); got != 0 {
+		t.Errorf("polar night: got day length %s, want 0", got)
+	}
+	// the sun is just below the horizon at noon, so there is civil twilight.
+	if midwinter.CivilDawn.IsZero() || midwinter.CivilDusk.IsZero() {
+		t.Error("polar night: no civil twilight")
+	}
+	if elevation, _ := Position(midwinter.SolarNoon, lat, lon); elevation > 0 || elevation < -6 {
+		t.Errorf("polar night: got elevation %.2f at noon, want it within the civil twilight", elevation)
+	}
+
+	// and a regular day has the length between sunrise and sunset.
+	spring := Calculate(time.Date(2024, 3, 20, 0, 0, 0, 0, oslo), lat, lon)
+	if got, want := spring.DayLength(), spring.Sunset.Sub(spring.Sunrise); got != want || got < 12*time.Hour {
+		t.Errorf("equinox: got day length %s, want %s", got, want)
+	}
+}
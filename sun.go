@@ -0,0 +1,56 @@
+package openweathermap
+
+import (
+	"time"
+
+	"github.com/insomniacslk/openweathermap/solar"
+)
+
+// IsDaytime returns true if the sun is up at the given Unix time at the
+// requested location. It is calculated offline with the solar package.
+func (w *Weather) IsDaytime(unix int64) bool {
+	return solar.IsDaytime(time.Unix(unix, 0), w.Lat, w.Lon)
+}
+
+// Icon returns the icon code of the main weather condition of the given
+// summary, e.g. "10n", choosing between the day and night variants by the
+// position of the sun at the summary's time. This also works for the hourly
+// summaries, that have no sunrise and sunset. Night codes like "09n" share
+// the image of the day variant, so the image should be loaded with
+// icons.Lookup, that falls back to it. An empty string is returned if there
+// is no known condition.
+func (w *Weather) Icon(s *CommonWeatherSummary) string {
+	if len(s.Weather) == 0 {
+		return ""
+	}
+	return s.Weather[0].ID.Icon(w.IsDaytime(s.Dt))
+}
+
+// FillSunTimes sets the sunrise and sunset of all the summaries that lack
+// them, like the hourly ones, calculating them offline with the solar
+// package for the day of each summary in the location's time zone. Where
+// the sun does not rise or set, the values are left at zero.
+func (w *Weather) FillSunTimes() {
+	loc := w.Location()
+	fill := func(s *CommonWeatherSummary) {
+		if s.Dt == 0 || (s.Sunrise != 0 && s.Sunset != 0) {
+			return
+		}
+		times := solar.Calculate(s.Time(loc), w.Lat, w.Lon)
+		if s.Sunrise == 0 && !times.Sunrise.IsZero() {
+			s.Sunrise = int(times.Sunrise.Unix())
+		}
+		if s.Sunset == 0 && !times.Sunset.IsZero() {
+			s.Sunset = int(times.Sunset.Unix())
+		}
+	}
+	if w.Current != nil {
+		fill(&w.Current.CommonWeatherSummary)
+	}
+	for idx := range w.Hourly {
+		fill(&w.Hourly[idx].CommonWeatherSummary)
+	}
+	for idx := range w.Daily {
+		fill(&w.Daily[idx].CommonWeatherSummary)
+	}
+}
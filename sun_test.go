@@ -0,0 +1,28 @@
+package openweathermap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insomniacslk/openweathermap/icons"
+)
+
+func TestWeatherIconAtNight(t *testing.T) {
+	// Rome at midnight UTC, with rain.
+	w := Weather{Lat: 41.9, Lon: 12.5}
+	var s CommonWeatherSummary
+	s.Dt = 1704067200 // 2024-01-01 00:00:00 UTC
+	s.Weather = append(s.Weather, struct {
+		ID          ConditionCode `json:"id"`
+		Main        string        `json:"main"`
+		Description string        `json:"description"`
+		Icon        string        `json:"icon"`
+	}{ID: LightRain})
+	icon := w.Icon(&s)
+	if icon != "10n" {
+		t.Fatalf("got icon %q, want %q", icon, "10n")
+	}
+	if night, ok := icons.Lookup(icon); ok && bytes.Equal(night, icons.Icons["10d"]) {
+		t.Errorf("icon %q is the day image", icon)
+	}
+}
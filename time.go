@@ -0,0 +1,100 @@
+package openweathermap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insomniacslk/openweathermap/internal/apiutil"
+)
+
+// locations caches the time zones loaded from the tz database by name, since
+// time.LoadLocation reads them from disk on every call.
+var locations sync.Map
+
+// location returns the time zone with the given tz database name, falling
+// back to a fixed zone with the given offset in seconds if the name is empty
+// or the tz database is not available.
+func location(name string, offset int) *time.Location {
+	if name != "" {
+		if loc, ok := locations.Load(name); ok {
+			return loc.(*time.Location)
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			locations.Store(name, loc)
+			return loc
+		}
+	}
+	return time.FixedZone(name, offset)
+}
+
+// Location returns the time zone of the requested location, loaded from the
+// tz database by Timezone, or built from TimezoneOffset if that fails.
+func (w *Weather) Location() *time.Location {
+	return location(w.Timezone, w.TimezoneOffset)
+}
+
+// Time converts a Unix timestamp from the response into a time in the
+// requested location's time zone.
+func (w *Weather) Time(unix int64) time.Time {
+	return apiutil.UnixTime(unix, w.Location())
+}
+
+// Location returns the time zone of the requested location, loaded from the
+// tz database by Timezone, or built from TimezoneOffset if that fails.
+func (t *TimeMachine) Location() *time.Location {
+	return location(t.Timezone, t.TimezoneOffset)
+}
+
+// Time converts a Unix timestamp from the response into a time in the
+// requested location's time zone.
+func (t *TimeMachine) Time(unix int64) time.Time {
+	return apiutil.UnixTime(unix, t.Location())
+}
+
+// Time returns the time of the data point in the given location, usually
+// obtained with Weather.Location. If loc is nil, UTC is used.
+func (s *CommonWeatherSummary) Time(loc *time.Location) time.Time {
+	return apiutil.UnixTime(s.Dt, loc)
+}
+
+// SunriseTime returns the sunrise time in the given location, or the zero
+// time if not available. If loc is nil, UTC is used.
+func (s *CommonWeatherSummary) SunriseTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(int64(s.Sunrise), loc)
+}
+
+// SunsetTime returns the sunset time in the given location, or the zero time
+// if not available. If loc is nil, UTC is used.
+func (s *CommonWeatherSummary) SunsetTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(int64(s.Sunset), loc)
+}
+
+// MoonriseTime returns the moonrise time in the given location, or the zero
+// time if the moon does not rise on that day. If loc is nil, UTC is used.
+func (s *DailyWeatherSummary) MoonriseTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(s.Moonrise, loc)
+}
+
+// MoonsetTime returns the moonset time in the given location, or the zero
+// time if the moon does not set on that day. If loc is nil, UTC is used.
+func (s *DailyWeatherSummary) MoonsetTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(s.Moonset, loc)
+}
+
+// Time returns the time of the data point in the given location. If loc is
+// nil, UTC is used.
+func (m *MinutelyPrecipitation) Time(loc *time.Location) time.Time {
+	return apiutil.UnixTime(m.Dt, loc)
+}
+
+// StartTime returns the start of the alert in the given location. If loc is
+// nil, UTC is used.
+func (a *Alert) StartTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(a.Start, loc)
+}
+
+// EndTime returns the end of the alert in the given location. If loc is nil,
+// UTC is used.
+func (a *Alert) EndTime(loc *time.Location) time.Time {
+	return apiutil.UnixTime(a.End, loc)
+}
@@ -0,0 +1,18 @@
+package openweathermap
+
+import "testing"
+
+func TestLocationCached(t *testing.T) {
+	w := Weather{Timezone: "Europe/Rome", TimezoneOffset: 3600}
+	first := w.Location()
+	if first.String() != "Europe/Rome" {
+		t.Skipf("tz database not available: got %s", first)
+	}
+	if second := w.Location(); second != first {
+		t.Error("the location was loaded again instead of being cached")
+	}
+	w = Weather{Timezone: "Not/AZone", TimezoneOffset: 7200}
+	if _, offset := w.Time(1700000000).Zone(); offset != 7200 {
+		t.Errorf("got offset %d, want the fallback offset 7200", offset)
+	}
+}
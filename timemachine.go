@@ -0,0 +1,51 @@
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeMachine maps to a JSON response from the One Call API's timemachine
+// endpoint, that returns the weather data for a given point in time.
+type TimeMachine struct {
+	Lat            float64               `json:"lat"`
+	Lon            float64               `json:"lon"`
+	Timezone       string                `json:"timezone"`
+	TimezoneOffset int                   `json:"timezone_offset"`
+	Data           []PointWeatherSummary `json:"data"`
+}
+
+// TimeMachine returns the weather data for the given point in time, from
+// 1st January 1979 up to four days ahead, see
+// https://openweathermap.org/api/one-call-3#history . If units or lang are
+// empty, the client's defaults are used.
+func (c *Client) TimeMachine(lat, lon float64, dt time.Time, units Units, lang Lang) (*TimeMachine, error) {
+	return c.TimeMachineContext(context.Background(), lat, lon, dt, units, lang)
+}
+
+// TimeMachineContext is like TimeMachine, but the request is bound to the
+// given context.
+func (c *Client) TimeMachineContext(ctx context.Context, lat, lon float64, dt time.Time, units Units, lang Lang) (*TimeMachine, error) {
+	u, err := c.URL(OneCallAPI, "/timemachine")
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	setCoordinates(q, lat, lon)
+	q.Set("dt", strconv.FormatInt(dt.Unix(), 10))
+	c.setUnitsLang(q, units, lang)
+	u.RawQuery = q.Encode()
+
+	body, err := c.GetContext(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	var apiResp TimeMachine
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+	return &apiResp, nil
+}
@@ -0,0 +1,165 @@
+package openweathermap
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// WindDirection is a meteorological wind direction in degrees, i.e. the
+// direction the wind is coming from, measured clockwise from north.
+type WindDirection float64
+
+var compass8 = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+var compass16 = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+var compass32 = []string{
+	"N", "NbE", "NNE", "NEbN", "NE", "NEbE", "ENE", "EbN",
+	"E", "EbS", "ESE", "SEbE", "SE", "SEbS", "SSE", "SbE",
+	"S", "SbW", "SSW", "SWbS", "SW", "SWbW", "WSW", "WbS",
+	"W", "WbN", "WNW", "NWbW", "NW", "NWbN", "NNW", "NbW",
+}
+
+// arrows pointing where the wind blows to, starting from north and going
+// clockwise.
+var arrows = []string{"↑", "↗", "→", "↘", "↓", "↙", "←", "↖"}
+
+// cardinalPoints maps a language to the abbreviations of north, east, south
+// and west, used to localize the compass point names. Only the languages
+// that compose the intercardinal points from the cardinal ones in the same
+// order as English are listed, e.g. "NO" in German or "北東" in Japanese for
+// north-east. Languages that use distinct words for them, like Finnish
+// ("koillinen") or Indonesian ("timur laut"), or that compose them in the
+// opposite order, like Chinese ("东北"), are not.
+var cardinalPoints = map[Lang][4]string{
+	AF:    {"N", "O", "S", "W"},
+	AL:    {"V", "L", "J", "P"},
+	AZ:    {"Şm", "Şr", "C", "Q"},
+	BG:    {"С", "И", "Ю", "З"},
+	CA:    {"N", "E", "S", "O"},
+	CZ:    {"S", "V", "J", "Z"},
+	DA:    {"N", "Ø", "S", "V"},
+	DE:    {"N", "O", "S", "W"},
+	EL:    {"Β", "Α", "Ν", "Δ"},
+	EN:    {"N", "E", "S", "W"},
+	ES:    {"N", "E", "S", "O"},
+	EU:    {"I", "E", "H", "M"},
+	FR:    {"N", "E", "S", "O"},
+	GL:    {"N", "L", "S", "O"},
+	HR:    {"S", "I", "J", "Z"},
+	HU:    {"É", "K", "D", "Ny"},
+	IT:    {"N", "E", "S", "O"},
+	JA:    {"北", "東", "南", "西"},
+	KR:    {"북", "동", "남", "서"},
+	LA:    {"Z", "A", "D", "R"},
+	LT:    {"Š", "R", "P", "V"},
+	MK:    {"С", "И", "Ј", "З"},
+	NL:    {"N", "O", "Z", "W"},
+	NO:    {"N", "Ø", "S", "V"},
+	PL:    {"N", "E", "S", "W"},
+	PT:    {"N", "L", "S", "O"},
+	PT_BR: {"N", "L", "S", "O"},
+	RO:    {"N", "E", "S", "V"},
+	RU:    {"С", "В", "Ю", "З"},
+	SE:    {"N", "Ö", "S", "V"},
+	SK:    {"S", "V", "J", "Z"},
+	SL:    {"S", "V", "J", "Z"},
+	SP:    {"N", "E", "S", "O"},
+	SR:    {"S", "I", "J", "Z"},
+	SV:    {"N", "Ö", "S", "V"},
+	TR:    {"K", "D", "G", "B"},
+	UA:    {"Пн", "Сх", "Пд", "Зх"},
+	UK:    {"Пн", "Сх", "Пд", "Зх"},
+}
+
+// Degrees returns the direction normalized to the [0, 360) range.
+func (d WindDirection) Degrees() float64 {
+	deg := math.Mod(float64(d), 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// point returns the index of the nearest of n equally spaced compass points.
+func (d WindDirection) point(n int) int {
+	return int(math.Round(d.Degrees()/(360/float64(n)))) % n
+}
+
+// Compass8 returns the name of the nearest point of an 8-point compass rose,
+// e.g. "NE".
+func (d WindDirection) Compass8() string {
+	return compass8[d.point(8)]
+}
+
+// Compass16 returns the name of the nearest point of a 16-point compass
+// rose, e.g. "NNE".
+func (d WindDirection) Compass16() string {
+	return compass16[d.point(16)]
+}
+
+// Compass32 returns the name of the nearest point of a 32-point compass
+// rose, e.g. "NbE" for north by east.
+func (d WindDirection) Compass32() string {
+	return compass32[d.point(32)]
+}
+
+// Arrow returns an arrow glyph pointing where the wind blows to, e.g. "↓"
+// for a northerly wind.
+func (d WindDirection) Arrow() string {
+	return arrows[WindDirection(d.Degrees()+180).point(8)]
+}
+
+// Abbrev returns the abbreviation of the nearest point of a 16-point
+// compass rose in the given language, e.g. "NNO" for north-north-east in
+// German. Languages that are not listed in cardinalPoints fall back to
+// English.
+func (d WindDirection) Abbrev(lang Lang) string {
+	points, ok := cardinalPoints[lang]
+	if !ok {
+		points = cardinalPoints[EN]
+	}
+	replacer := strings.NewReplacer("N", points[0], "E", points[1], "S", points[2], "W", points[3])
+	return replacer.Replace(d.Compass16())
+}
+
+func (d WindDirection) String() string {
+	return fmt.Sprintf("%.0f° %s", d.Degrees(), d.Compass16())
+}
+
+// MeanWindDirection returns the circular mean of the given directions, so
+// that e.g. 350° and 10° average to 0° rather than 180°. It returns false if
+// the mean is undefined, i.e. if there are no directions or if they cancel
+// each other out.
+func MeanWindDirection(dirs []WindDirection) (WindDirection, bool) {
+	var sumSin, sumCos float64
+	for _, d := range dirs {
+		rad := float64(d) * math.Pi / 180
+		sumSin += math.Sin(rad)
+		sumCos += math.Cos(rad)
+	}
+	if math.Hypot(sumSin, sumCos) < 1e-9 {
+		return 0, false
+	}
+	mean := WindDirection(math.Atan2(sumSin, sumCos) * 180 / math.Pi)
+	return WindDirection(mean.Degrees()), true
+}
+
+// MeanHourlyWindDirection returns the circular mean of the wind directions
+// of the given hourly summaries. See MeanWindDirection.
+func MeanHourlyWindDirection(hourly []PointWeatherSummary) (WindDirection, bool) {
+	dirs := make([]WindDirection, 0, len(hourly))
+	for _, h := range hourly {
+		dirs = append(dirs, h.WindDirection())
+	}
+	return MeanWindDirection(dirs)
+}
+
+// WindDirection returns the wind direction.
+func (s *CommonWeatherSummary) WindDirection() WindDirection {
+	return WindDirection(s.WindDeg)
+}
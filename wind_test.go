@@ -0,0 +1,38 @@
+package openweathermap
+
+import "testing"
+
+func TestWindDirectionAbbrev(t *testing.T) {
+	dirs := []WindDirection{0, 22.5, 45, 67.5, 135, 202.5, 292.5}
+	for _, tc := range []struct {
+		lang Lang
+		want []string
+	}{
+		{EN, []string{"N", "NNE", "NE", "ENE", "SE", "SSW", "WNW"}},
+		{DE, []string{"N", "NNO", "NO", "ONO", "SO", "SSW", "WNW"}},
+		{FR, []string{"N", "NNE", "NE", "ENE", "SE", "SSO", "ONO"}},
+		{IT, []string{"N", "NNE", "NE", "ENE", "SE", "SSO", "ONO"}},
+		{PT, []string{"N", "NNL", "NL", "LNL", "SL", "SSO", "ONO"}},
+		{NL, []string{"N", "NNO", "NO", "ONO", "ZO", "ZZW", "WNW"}},
+		{SV, []string{"N", "NNÖ", "NÖ", "ÖNÖ", "SÖ", "SSV", "VNV"}},
+		{HU, []string{"É", "ÉÉK", "ÉK", "KÉK", "DK", "DDNy", "NyÉNy"}},
+		{RU, []string{"С", "ССВ", "СВ", "ВСВ", "ЮВ", "ЮЮЗ", "ЗСЗ"}},
+		{UK, []string{"Пн", "ПнПнСх", "ПнСх", "СхПнСх", "ПдСх", "ПдПдЗх", "ЗхПнЗх"}},
+		{UA, []string{"Пн", "ПнПнСх", "ПнСх", "СхПнСх", "ПдСх", "ПдПдЗх", "ЗхПнЗх"}},
+		{JA, []string{"北", "北北東", "北東", "東北東", "南東", "南南西", "西北西"}},
+		{KR, []string{"북", "북북동", "북동", "동북동", "남동", "남남서", "서북서"}},
+		{AZ, []string{"Şm", "ŞmŞmŞr", "ŞmŞr", "ŞrŞmŞr", "CŞr", "CCQ", "QŞmQ"}},
+		{TR, []string{"K", "KKD", "KD", "DKD", "GD", "GGB", "BKB"}},
+		// languages that do not compose the intercardinal points fall back
+		// to English.
+		{FI, []string{"N", "NNE", "NE", "ENE", "SE", "SSW", "WNW"}},
+		{ID, []string{"N", "NNE", "NE", "ENE", "SE", "SSW", "WNW"}},
+		{ZH_CN, []string{"N", "NNE", "NE", "ENE", "SE", "SSW", "WNW"}},
+	} {
+		for i, d := range dirs {
+			if got := d.Abbrev(tc.lang); got != tc.want[i] {
+				t.Errorf("%s %v: got %q, want %q", tc.lang, d, got, tc.want[i])
+			}
+		}
+	}
+}